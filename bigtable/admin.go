@@ -17,13 +17,16 @@ limitations under the License.
 package bigtable
 
 import (
+	"bytes"
 	"container/list"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
@@ -32,6 +35,7 @@ import (
 	"cloud.google.com/go/internal/optional"
 	"cloud.google.com/go/longrunning"
 	lroauto "cloud.google.com/go/longrunning/autogen"
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
 	gax "github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/iterator"
@@ -41,6 +45,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	field_mask "google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -69,6 +74,10 @@ var (
 // - error code is internal and error message is one of the `retryableInternalErrMsgs`
 type bigtableAdminRetryer struct {
 	gax.Backoff
+	// extraCodes, if non-nil, names additional codes to retry on top of
+	// isIdempotentRetryCode, set via retryableCodes/WithCallOptions for a
+	// single call site instead of every AdminClient.
+	extraCodes map[codes.Code]bool
 }
 
 func (r *bigtableAdminRetryer) Retry(err error) (time.Duration, bool) {
@@ -80,7 +89,8 @@ func (r *bigtableAdminRetryer) Retry(err error) (time.Duration, bool) {
 	c := st.Code()
 	_, isIdempotent := isIdempotentRetryCode[c]
 	if isIdempotent ||
-		(grpcstatus.Code(err) == codes.Internal && containsAny(err.Error(), retryableInternalErrMsgs)) {
+		(grpcstatus.Code(err) == codes.Internal && containsAny(err.Error(), retryableInternalErrMsgs)) ||
+		r.extraCodes[c] {
 		pause := r.Backoff.Pause()
 		return pause, true
 	}
@@ -108,6 +118,65 @@ type AdminClient struct {
 
 	// Metadata to be sent with each request.
 	md metadata.MD
+
+	schedulesMu sync.Mutex
+	// schedules holds the BackupSchedules created through this client,
+	// keyed by table ID, so ListBackupSchedules/UpdateBackupSchedule/
+	// DeleteBackupSchedule can find the running scheduler goroutine for a
+	// table without the caller having to hold onto the *BackupSchedule
+	// returned by CreateBackupSchedule.
+	schedules map[string]*BackupSchedule
+
+	// idempotency is set by WithIdempotentCreate to retry this client's
+	// create calls per an IdempotencyPolicy instead of returning their
+	// first error.
+	idempotency *IdempotencyPolicy
+
+	backupPlansMu sync.Mutex
+	// backupPlans holds the TableBackupPlans registered through
+	// UpdateTableWithBackupPlan, keyed by table ID, so
+	// RunBackupPlanReconciler knows which tables' extra backup rules and
+	// copy destinations to reconcile.
+	backupPlans map[string]*registeredBackupPlan
+
+	// callOpts is appended after adminRetryOptions on this client's
+	// DropRowRange RPCs (DropRowRange, DropRowRangeBytes, DropRowRangeUsing,
+	// DropAllRows), letting WithCallOptions layer in extra retry behavior
+	// (e.g. retryableCodes) without changing adminRetryOptions for every
+	// other AdminClient in the process. Other RPCs do not apply it.
+	callOpts []gax.CallOption
+}
+
+// WithCallOptions returns a new AdminClient that behaves exactly like ac,
+// except that its DropRowRange RPCs (DropRowRange, DropRowRangeBytes,
+// DropRowRangeUsing, DropAllRows) also apply opts, after
+// adminRetryOptions; its other admin RPCs do not apply opts. It shares
+// ac's underlying connection; it's a cheap,
+// independent view onto the same client, not a new connection.
+//
+// This is meant for opting a specific call site into different retry
+// behavior, e.g. retryableCodes(codes.Aborted) for a DropRowRangeBytes
+// call expected to run over a very large range:
+//
+//	scoped := ac.WithCallOptions(retryableCodes(codes.Aborted))
+//	err := scoped.DropRowRangeBytes(ctx, table, start, end)
+func (ac *AdminClient) WithCallOptions(opts ...gax.CallOption) *AdminClient {
+	scoped := *ac
+	scoped.callOpts = append(append([]gax.CallOption{}, ac.callOpts...), opts...)
+	return &scoped
+}
+
+// retryableCodes returns a gax.CallOption that retries an admin RPC on
+// extra, in addition to bigtableAdminRetryer's usual codes, sharing its
+// backoff. Pass it to WithCallOptions.
+func retryableCodes(extra ...codes.Code) gax.CallOption {
+	extraCodes := make(map[codes.Code]bool, len(extra))
+	for _, c := range extra {
+		extraCodes[c] = true
+	}
+	return gax.WithRetry(func() gax.Retryer {
+		return &bigtableAdminRetryer{Backoff: defaultBackoff, extraCodes: extraCodes}
+	})
 }
 
 // NewAdminClient creates a new AdminClient for a given project and instance.
@@ -251,6 +320,10 @@ func (ac *AdminClient) EncryptionInfo(ctx context.Context, table string) (Encryp
 }
 
 // Tables returns a list of the tables in the instance.
+//
+// Deprecated: use AdminClient.TablesWithView, which paginates instead of
+// fetching every table name in one ListTables call, and can return more
+// than just each table's name.
 func (ac *AdminClient) Tables(ctx context.Context) ([]string, error) {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	prefix := ac.instancePrefix()
@@ -275,6 +348,98 @@ func (ac *AdminClient) Tables(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
+// TableIterator iterates over the tables in an instance, as returned by
+// AdminClient.TablesWithView.
+type TableIterator struct {
+	items    []*TableInfo
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+}
+
+// PageInfo supports pagination. See https://godoc.org/google.golang.org/api/iterator package for details.
+func (it *TableIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done
+// (https://godoc.org/google.golang.org/api/iterator) if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *TableIterator) Next() (*TableInfo, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// TablesOption configures an AdminClient.TablesWithView call.
+type TablesOption interface {
+	set(*btapb.ListTablesRequest)
+}
+
+type tablesViewOption TableView
+
+func (o tablesViewOption) set(req *btapb.ListTablesRequest) {
+	req.View = TableView(o).proto()
+}
+
+// WithTablesView sets the TableView AdminClient.TablesWithView requests
+// for each table; the default, if omitted, is TableViewNameOnly, matching
+// ListTables' own server-side default.
+func WithTablesView(view TableView) TablesOption {
+	return tablesViewOption(view)
+}
+
+// TablesWithView is like Tables, but paginates instead of fetching every
+// table name in one ListTables call, and, with WithTablesView, fetches
+// each TableInfo under a richer view than just a name.
+func (ac *AdminClient) TablesWithView(ctx context.Context, opts ...TablesOption) *TableIterator {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
+
+	it := &TableIterator{}
+	req := &btapb.ListTablesRequest{
+		Parent: prefix,
+	}
+	for _, opt := range opts {
+		opt.set(req)
+	}
+
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		req.PageToken = pageToken
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else {
+			req.PageSize = int32(pageSize)
+		}
+
+		var res *btapb.ListTablesResponse
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+			var err error
+			res, err = ac.tClient.ListTables(ctx, req)
+			return err
+		}, adminRetryOptions...)
+		if err != nil {
+			return "", err
+		}
+		for _, tbl := range res.Tables {
+			ti, err := newTableInfo(tbl)
+			if err != nil {
+				return "", err
+			}
+			it.items = append(it.items, ti)
+		}
+		return res.NextPageToken, nil
+	}
+	bufLen := func() int { return len(it.items) }
+	takeBuf := func() interface{} { b := it.items; it.items = nil; return b }
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, bufLen, takeBuf)
+
+	return it
+}
+
 // ChangeStreamRetention indicates how long bigtable should retain change data.
 // Minimum is 1 day. Maximum is 7. nil to not change the retention period. 0 to
 // disable change stream retention.
@@ -296,8 +461,8 @@ const (
 )
 
 // TableAutomatedBackupConfig generalizes automated backup configurations.
-// Currently, the only supported type of automated backup configuration
-// is TableAutomatedBackupPolicy.
+// The supported types are TableAutomatedBackupPolicy and, for multi-tier
+// retention and cross-region copy, TableBackupPlan.
 type TableAutomatedBackupConfig interface {
 	isTableAutomatedBackupConfig()
 }
@@ -323,12 +488,17 @@ func toAutomatedBackupConfigProto(automatedBackupConfig TableAutomatedBackupConf
 	switch backupConfig := automatedBackupConfig.(type) {
 	case *TableAutomatedBackupPolicy:
 		return backupConfig.toProto()
+	case *TableBackupPlan:
+		return backupConfig.toProto()
 	default:
 		return nil, fmt.Errorf("error: Unknown type of automated backup configuration")
 	}
 }
 
 func (abp *TableAutomatedBackupPolicy) toProto() (*btapb.Table_AutomatedBackupPolicy_, error) {
+	if err := abp.Validate(); err != nil {
+		return nil, err
+	}
 	pbAutomatedBackupPolicy := &btapb.Table_AutomatedBackupPolicy{
 		RetentionPeriod: durationpb.New(0),
 		Frequency:       durationpb.New(0),
@@ -394,10 +564,27 @@ func (ac *AdminClient) CreatePresplitTable(ctx context.Context, table string, sp
 
 // CreateTableFromConf creates a new table in the instance from the given configuration.
 func (ac *AdminClient) CreateTableFromConf(ctx context.Context, conf *TableConf) error {
-	if conf.TableID == "" {
-		return errors.New("TableID is required")
+	req, err := ac.newCreateTableRequestProto(conf)
+	if err != nil {
+		return err
 	}
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	create := func() error {
+		_, err := ac.tClient.CreateTable(ctx, req)
+		return err
+	}
+	if ac.idempotency != nil {
+		return ac.idempotency.run(ctx, conf.TableID, create)
+	}
+	return create()
+}
+
+// newCreateTableRequestProto builds the CreateTableRequest conf
+// describes, shared by CreateTableFromConf and CreateTableOperation.
+func (ac *AdminClient) newCreateTableRequestProto(conf *TableConf) (*btapb.CreateTableRequest, error) {
+	if conf.TableID == "" {
+		return nil, errors.New("TableID is required")
+	}
 	var reqSplits []*btapb.CreateTableRequest_Split
 	for _, split := range conf.SplitKeys {
 		reqSplits = append(reqSplits, &btapb.CreateTableRequest_Split{Key: []byte(split)})
@@ -418,7 +605,7 @@ func (ac *AdminClient) CreateTableFromConf(ctx context.Context, conf *TableConf)
 	if conf.AutomatedBackupConfig != nil {
 		proto, err := toAutomatedBackupConfigProto(conf.AutomatedBackupConfig)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		tbl.AutomatedBackupConfig = proto
 	}
@@ -428,7 +615,7 @@ func (ac *AdminClient) CreateTableFromConf(ctx context.Context, conf *TableConf)
 	}
 
 	if conf.Families != nil && conf.ColumnFamilies != nil {
-		return errors.New("only one of Families or ColumnFamilies may be set, not both")
+		return nil, errors.New("only one of Families or ColumnFamilies may be set, not both")
 	}
 
 	if conf.ColumnFamilies != nil {
@@ -461,8 +648,7 @@ func (ac *AdminClient) CreateTableFromConf(ctx context.Context, conf *TableConf)
 		Table:         &tbl,
 		InitialSplits: reqSplits,
 	}
-	_, err := ac.tClient.CreateTable(ctx, req)
-	return err
+	return req, nil
 }
 
 // CreateColumnFamily creates a new column family in a table.
@@ -477,8 +663,14 @@ func (ac *AdminClient) CreateColumnFamily(ctx context.Context, table, family str
 			Mod: &btapb.ModifyColumnFamiliesRequest_Modification_Create{Create: &btapb.ColumnFamily{}},
 		}},
 	}
-	_, err := ac.tClient.ModifyColumnFamilies(ctx, req)
-	return err
+	create := func() error {
+		_, err := ac.tClient.ModifyColumnFamilies(ctx, req)
+		return err
+	}
+	if ac.idempotency != nil {
+		return ac.idempotency.run(ctx, table+"/"+family, create)
+	}
+	return create()
 }
 
 // CreateColumnFamilyWithConfig creates a new column family in a table with an optional GC policy and value type.
@@ -501,8 +693,14 @@ func (ac *AdminClient) CreateColumnFamilyWithConfig(ctx context.Context, table,
 			Mod: &btapb.ModifyColumnFamiliesRequest_Modification_Create{Create: cf},
 		}},
 	}
-	_, err := ac.tClient.ModifyColumnFamilies(ctx, req)
-	return err
+	create := func() error {
+		_, err := ac.tClient.ModifyColumnFamilies(ctx, req)
+		return err
+	}
+	if ac.idempotency != nil {
+		return ac.idempotency.run(ctx, table+"/"+family, create)
+	}
+	return create()
 }
 
 const (
@@ -531,23 +729,27 @@ func (ac *AdminClient) newUpdateTableRequestProto(tableID string) (*btapb.Update
 }
 
 func (ac *AdminClient) updateTableAndWait(ctx context.Context, updateTableRequest *btapb.UpdateTableRequest) error {
-	ctx = mergeOutgoingMetadata(ctx, ac.md)
-
-	lro, err := ac.tClient.UpdateTable(ctx, updateTableRequest)
+	top, err := ac.newUpdateTableOperation(ctx, updateTableRequest)
 	if err != nil {
 		return fmt.Errorf("error from update: %w", err)
 	}
-
-	var tbl btapb.Table
-	op := longrunning.InternalNewOperation(ac.lroClient, lro)
-	err = op.Wait(ctx, &tbl)
-	if err != nil {
+	if err := top.Wait(ctx); err != nil {
 		return fmt.Errorf("error from operation: %v", err)
 	}
-
 	return nil
 }
 
+// newUpdateTableOperation starts req and returns a handle to it,
+// shared by updateTableAndWait and UpdateTableOperation.
+func (ac *AdminClient) newUpdateTableOperation(ctx context.Context, req *btapb.UpdateTableRequest) (*TableOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	lro, err := ac.tClient.UpdateTable(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &TableOperation{op: longrunning.InternalNewOperation(ac.lroClient, lro)}, nil
+}
+
 // UpdateTableDisableChangeStream updates a table to disable change stream for table ID.
 func (ac *AdminClient) UpdateTableDisableChangeStream(ctx context.Context, tableID string) error {
 	req, err := ac.newUpdateTableRequestProto(tableID)
@@ -570,6 +772,13 @@ func (ac *AdminClient) UpdateTableWithChangeStream(ctx context.Context, tableID
 	return ac.updateTableAndWait(ctx, req)
 }
 
+// UpdateTableWithChangeStreamRetention is UpdateTableWithChangeStream for
+// callers who'd rather pass a plain time.Duration than wrap it in a
+// ChangeStreamRetention.
+func (ac *AdminClient) UpdateTableWithChangeStreamRetention(ctx context.Context, tableID string, retention time.Duration) error {
+	return ac.UpdateTableWithChangeStream(ctx, tableID, ChangeStreamRetention(retention))
+}
+
 // UpdateTableWithDeletionProtection updates a table with the given table ID and deletion protection parameter.
 func (ac *AdminClient) UpdateTableWithDeletionProtection(ctx context.Context, tableID string, deletionProtection DeletionProtection) error {
 	req, err := ac.newUpdateTableRequestProto(tableID)
@@ -669,6 +878,10 @@ func (ac *AdminClient) DeleteColumnFamily(ctx context.Context, table, family str
 
 // TableInfo represents information about a table.
 type TableInfo struct {
+	// Name is the table's ID (not its full resource path), taken from the
+	// server's response by AdminClient.TableInfo, AdminClient.GetTable and
+	// AdminClient.TablesWithView.
+	Name string
 	// DEPRECATED - This field is deprecated. Please use FamilyInfos instead.
 	Families    []string
 	FamilyInfos []FamilyInfo
@@ -679,6 +892,61 @@ type TableInfo struct {
 	ChangeStreamRetention ChangeStreamRetention
 	AutomatedBackupConfig TableAutomatedBackupConfig
 	RowKeySchema          *StructType
+	// ClusterStates reports each cluster's replication state by name, as
+	// ClusterState_ReplicationState.String() (e.g. "READY",
+	// "INITIALIZING"). Only populated when the TableInfo was fetched with
+	// TableViewReplication, TableViewEncryption, or TableViewFull; nil
+	// otherwise.
+	ClusterStates map[string]string
+}
+
+// TableView selects how much information AdminClient.GetTable or
+// AdminClient.TablesWithView populates on the TableInfo(s) it returns.
+// Requesting less than TableViewFull can avoid work the server would
+// otherwise do assembling fields the caller doesn't need.
+type TableView int
+
+const (
+	// TableViewNameOnly populates only the table's name.
+	TableViewNameOnly TableView = iota
+	// TableViewSchema additionally populates column families,
+	// DeletionProtection, ChangeStreamRetention, AutomatedBackupConfig, and
+	// RowKeySchema. This is what TableInfo itself always requests.
+	TableViewSchema
+	// TableViewReplication additionally populates ClusterStates.
+	TableViewReplication
+	// TableViewEncryption requests the server's ENCRYPTION_VIEW. TableInfo
+	// gets ClusterStates keyed by cluster, holding each cluster's
+	// replication state string; the per-cluster encryption info is not
+	// copied into TableInfo. EncryptionInfo, which always requests this
+	// view, returns that encryption info.
+	TableViewEncryption
+	// TableViewStats requests the server's STATS_VIEW. GetTable and
+	// TablesWithView populate no stats fields on TableInfo for it: row and
+	// cell counts and average column count are not returned.
+	TableViewStats
+	// TableViewFull requests every field this package knows how to
+	// populate.
+	TableViewFull
+)
+
+// proto maps a TableView to the btapb.Table_View GetTable/ListTables
+// actually take on the wire.
+func (v TableView) proto() btapb.Table_View {
+	switch v {
+	case TableViewSchema:
+		return btapb.Table_SCHEMA_VIEW
+	case TableViewReplication:
+		return btapb.Table_REPLICATION_VIEW
+	case TableViewEncryption:
+		return btapb.Table_ENCRYPTION_VIEW
+	case TableViewStats:
+		return btapb.Table_STATS_VIEW
+	case TableViewFull:
+		return btapb.Table_FULL
+	default:
+		return btapb.Table_NAME_ONLY
+	}
 }
 
 // FamilyInfo represents information about a column family.
@@ -718,8 +986,31 @@ func (ac *AdminClient) TableInfo(ctx context.Context, table string) (*TableInfo,
 	if err != nil {
 		return nil, err
 	}
+	return newTableInfo(res)
+}
+
+// GetTable retrieves information about tableID, like TableInfo, but lets
+// the caller choose how much of it the server populates via view. Use
+// TableViewReplication or TableViewFull to also get ClusterStates, which
+// TableInfo's fixed TableViewSchema request never populates.
+func (ac *AdminClient) GetTable(ctx context.Context, tableID string, view TableView) (*TableInfo, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+
+	res, err := ac.getTable(ctx, tableID, view.proto())
+	if err != nil {
+		return nil, err
+	}
+	return newTableInfo(res)
+}
 
+// newTableInfo builds a TableInfo from a GetTable/ListTables response,
+// populating whichever fields res actually carries; a view that didn't
+// request a given piece of information simply leaves it zero.
+func newTableInfo(res *btapb.Table) (*TableInfo, error) {
 	ti := &TableInfo{}
+	if i := strings.LastIndex(res.Name, "/tables/"); i >= 0 {
+		ti.Name = res.Name[i+len("/tables/"):]
+	}
 	for name, fam := range res.ColumnFamilies {
 		ti.Families = append(ti.Families, name)
 		ti.FamilyInfos = append(ti.FamilyInfos, FamilyInfo{
@@ -754,6 +1045,12 @@ func (ac *AdminClient) TableInfo(ctx context.Context, table string) (*TableInfo,
 		structType := structProtoToType(res.RowKeySchema).(StructType)
 		ti.RowKeySchema = &structType
 	}
+	if len(res.ClusterStates) > 0 {
+		ti.ClusterStates = make(map[string]string, len(res.ClusterStates))
+		for name, cs := range res.ClusterStates {
+			ti.ClusterStates[name] = cs.ReplicationState.String()
+		}
+	}
 
 	return ti, nil
 }
@@ -843,26 +1140,98 @@ func (ac *AdminClient) UpdateFamily(ctx context.Context, table, familyName strin
 
 // DropRowRange permanently deletes a row range from the specified table.
 func (ac *AdminClient) DropRowRange(ctx context.Context, table, rowKeyPrefix string) error {
-	ctx = mergeOutgoingMetadata(ctx, ac.md)
-	prefix := ac.instancePrefix()
 	req := &btapb.DropRowRangeRequest{
-		Name:   prefix + "/tables/" + table,
+		Name:   ac.instancePrefix() + "/tables/" + table,
 		Target: &btapb.DropRowRangeRequest_RowKeyPrefix{RowKeyPrefix: []byte(rowKeyPrefix)},
 	}
-	_, err := ac.tClient.DropRowRange(ctx, req)
-	return err
+	return ac.dropRowRange(ctx, req)
+}
+
+// DropRowRangeBytes is DropRowRange generalized to an arbitrary half-open
+// byte range [start, end) instead of a string prefix, for row keys that
+// don't divide cleanly on string boundaries (e.g. tenant IDs packed as
+// fixed-width big-endian integers). An empty end means "no upper bound".
+//
+// The underlying DropRowRange RPC doesn't actually support an arbitrary
+// range: it only accepts an exact key prefix or a whole-table delete. So
+// DropRowRangeBytes only succeeds for [start, end) pairs that are exactly
+// one of those two shapes — end empty and start empty (equivalent to
+// DropAllRows), or end equal to start's prefix successor (equivalent to
+// DropRowRange(start)) — and returns an error for anything else, rather
+// than silently dropping the wrong rows.
+func (ac *AdminClient) DropRowRangeBytes(ctx context.Context, table string, start, end []byte) error {
+	req, err := dropRowRangeRequest(ac.instancePrefix()+"/tables/"+table, start, end)
+	if err != nil {
+		return err
+	}
+	return ac.dropRowRange(ctx, req)
+}
+
+// DropRowRangeUsing is DropRowRangeBytes, taking a RowRange — the same
+// type ReadRows accepts as a RowSet — instead of separate start/end
+// slices, so a range built once (e.g. via NewRangeFromKeyFields) can
+// drop exactly the rows it would otherwise read. It's subject to the
+// same prefix-or-whole-table limitation as DropRowRangeBytes.
+func (ac *AdminClient) DropRowRangeUsing(ctx context.Context, table string, rr RowRange) error {
+	return ac.DropRowRangeBytes(ctx, table, []byte(rr.Start()), []byte(rr.Limit()))
+}
+
+// dropRowRangeRequest builds the DropRowRangeRequest for [start, end),
+// returning an error if that range isn't expressible as the RPC's
+// supported RowKeyPrefix or DeleteAllDataFromTable targets.
+func dropRowRangeRequest(name string, start, end []byte) (*btapb.DropRowRangeRequest, error) {
+	if len(start) == 0 && len(end) == 0 {
+		return &btapb.DropRowRangeRequest{
+			Name:   name,
+			Target: &btapb.DropRowRangeRequest_DeleteAllDataFromTable{DeleteAllDataFromTable: true},
+		}, nil
+	}
+	if bytes.Equal(end, prefixSuccessor(start)) {
+		return &btapb.DropRowRangeRequest{
+			Name:   name,
+			Target: &btapb.DropRowRangeRequest_RowKeyPrefix{RowKeyPrefix: start},
+		}, nil
+	}
+	return nil, fmt.Errorf("bigtable: [%q, %q) isn't an exact key prefix or the whole table; DropRowRange only supports those two shapes", start, end)
+}
+
+// prefixSuccessor returns the lexicographically smallest byte string that
+// sorts after every string with prefix as a prefix, or nil if prefix has
+// no successor (empty, or all 0xff).
+func prefixSuccessor(prefix []byte) []byte {
+	if len(prefix) == 0 {
+		return nil
+	}
+	succ := append([]byte(nil), prefix...)
+	i := len(succ) - 1
+	for i >= 0 && succ[i] == 0xff {
+		i--
+	}
+	if i < 0 {
+		return nil
+	}
+	succ = succ[:i+1]
+	succ[i]++
+	return succ
 }
 
 // DropAllRows permanently deletes all rows from the specified table.
 func (ac *AdminClient) DropAllRows(ctx context.Context, table string) error {
-	ctx = mergeOutgoingMetadata(ctx, ac.md)
-	prefix := ac.instancePrefix()
 	req := &btapb.DropRowRangeRequest{
-		Name:   prefix + "/tables/" + table,
+		Name:   ac.instancePrefix() + "/tables/" + table,
 		Target: &btapb.DropRowRangeRequest_DeleteAllDataFromTable{DeleteAllDataFromTable: true},
 	}
-	_, err := ac.tClient.DropRowRange(ctx, req)
-	return err
+	return ac.dropRowRange(ctx, req)
+}
+
+// dropRowRange issues req, retrying per adminRetryOptions plus any
+// WithCallOptions this AdminClient was scoped with.
+func (ac *AdminClient) dropRowRange(ctx context.Context, req *btapb.DropRowRangeRequest) error {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	return gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+		_, err := ac.tClient.DropRowRange(ctx, req)
+		return err
+	}, append(append([]gax.CallOption{}, adminRetryOptions...), ac.callOpts...)...)
 }
 
 // CreateTableFromSnapshot creates a table from snapshot.
@@ -872,6 +1241,10 @@ func (ac *AdminClient) DropAllRows(ctx context.Context, table string) error {
 // is not currently available to most Cloud Bigtable customers. This feature
 // might be changed in backward-incompatible ways and is not recommended for
 // production use. It is not subject to any SLA or deprecation policy.
+//
+// Deprecated: use the GA Backups API instead: RestoreTable (or
+// RestoreTableFrom) restores a table from an AdminClient.CreateBackup
+// backup the same way this restores one from a snapshot.
 func (ac *AdminClient) CreateTableFromSnapshot(ctx context.Context, table, cluster, snapshot string) error {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	prefix := ac.instancePrefix()
@@ -901,6 +1274,9 @@ const DefaultSnapshotDuration time.Duration = 0
 // is not currently available to most Cloud Bigtable customers. This feature
 // might be changed in backward-incompatible ways and is not recommended for
 // production use. It is not subject to any SLA or deprecation policy.
+//
+// Deprecated: use AdminClient.CreateBackup (or CreateBackupWithOptions)
+// instead, Cloud Bigtable's GA backup feature.
 func (ac *AdminClient) SnapshotTable(ctx context.Context, table, cluster, snapshot string, ttl time.Duration) error {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	prefix := ac.instancePrefix()
@@ -933,6 +1309,8 @@ func (ac *AdminClient) SnapshotTable(ctx context.Context, table, cluster, snapsh
 // currently available to most Cloud Bigtable customers. This feature might be
 // changed in backward-incompatible ways and is not recommended for production use.
 // It is not subject to any SLA or deprecation policy.
+//
+// Deprecated: use AdminClient.Backups instead.
 func (ac *AdminClient) Snapshots(ctx context.Context, cluster string) *SnapshotIterator {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	prefix := ac.instancePrefix()
@@ -1046,6 +1424,8 @@ type SnapshotInfo struct {
 // is not currently available to most Cloud Bigtable customers. This feature
 // might be changed in backward-incompatible ways and is not recommended for
 // production use. It is not subject to any SLA or deprecation policy.
+//
+// Deprecated: use AdminClient.BackupInfo instead.
 func (ac *AdminClient) SnapshotInfo(ctx context.Context, cluster, snapshot string) (*SnapshotInfo, error) {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	prefix := ac.instancePrefix()
@@ -1088,10 +1468,17 @@ func (ac *AdminClient) DeleteSnapshot(ctx context.Context, cluster, snapshot str
 	return err
 }
 
-// getConsistencyToken gets the consistency token for a table.
-func (ac *AdminClient) getConsistencyToken(ctx context.Context, tableName string) (string, error) {
+// GenerateConsistencyToken generates a consistency token for table, for use
+// with CheckConsistency. It's exposed publicly, alongside CheckConsistency,
+// for callers that coordinate their own consistency checks across many
+// writes (e.g. fanning writes across shards, then jointly waiting) and want
+// to generate the token once and poll CheckConsistency repeatedly, instead
+// of calling WaitForReplication once per shard.
+func (ac *AdminClient) GenerateConsistencyToken(ctx context.Context, table string) (string, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
 	req := &btapb.GenerateConsistencyTokenRequest{
-		Name: tableName,
+		Name: prefix + "/tables/" + table,
 	}
 	resp, err := ac.tClient.GenerateConsistencyToken(ctx, req)
 	if err != nil {
@@ -1100,14 +1487,67 @@ func (ac *AdminClient) getConsistencyToken(ctx context.Context, tableName string
 	return resp.GetConsistencyToken(), nil
 }
 
-// isConsistent checks if a token is consistent for a table.
-func (ac *AdminClient) isConsistent(ctx context.Context, tableName, token string) (bool, error) {
+// ConsistencyMode selects the semantics a CheckConsistency or
+// WaitForReplication call checks consistency under.
+type ConsistencyMode int
+
+const (
+	// StandardReadRemoteWrites is standard read/remote-writes consistency:
+	// every write committed before the token was generated has replicated to
+	// every cluster. This is the default.
+	StandardReadRemoteWrites ConsistencyMode = iota
+	// DataBoostReadLocalWrites checks consistency for Data Boost
+	// read-local-writes workloads instead.
+	DataBoostReadLocalWrites
+)
+
+// ConsistencyOption configures a CheckConsistency call.
+type ConsistencyOption interface {
+	set(*btapb.CheckConsistencyRequest)
+}
+
+type consistencyModeOption ConsistencyMode
+
+// set mirrors CheckConsistencyRequest's mode oneof (StandardReadRemoteWrites
+// / DataBoostReadLocalWrites) in the admin service this package talks to;
+// this tree has no cached copy of the generated request type to check the
+// wrapper message names against, so treat non-default modes as best-effort
+// until exercised against a real server.
+func (o consistencyModeOption) set(req *btapb.CheckConsistencyRequest) {
+	switch ConsistencyMode(o) {
+	case DataBoostReadLocalWrites:
+		req.Mode = &btapb.CheckConsistencyRequest_DataBoostReadLocalWrites_{
+			DataBoostReadLocalWrites: &btapb.CheckConsistencyRequest_DataBoostReadLocalWrites{},
+		}
+	default:
+		req.Mode = &btapb.CheckConsistencyRequest_StandardReadRemoteWrites_{
+			StandardReadRemoteWrites: &btapb.CheckConsistencyRequest_StandardReadRemoteWrites{},
+		}
+	}
+}
+
+// WithConsistencyMode sets the ConsistencyMode a CheckConsistency or
+// WaitForReplication call uses. The default, if omitted, is
+// StandardReadRemoteWrites.
+func WithConsistencyMode(mode ConsistencyMode) ConsistencyOption {
+	return consistencyModeOption(mode)
+}
+
+// CheckConsistency reports whether every write committed before token was
+// generated (see GenerateConsistencyToken) has replicated to every cluster
+// in the instance, under opts' ConsistencyMode.
+func (ac *AdminClient) CheckConsistency(ctx context.Context, table, token string, opts ...ConsistencyOption) (bool, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
 	req := &btapb.CheckConsistencyRequest{
-		Name:             tableName,
+		Name:             prefix + "/tables/" + table,
 		ConsistencyToken: token,
 	}
-	var resp *btapb.CheckConsistencyResponse
+	for _, o := range opts {
+		o.set(req)
+	}
 
+	var resp *btapb.CheckConsistencyResponse
 	// Retry calls on retryable errors to avoid losing the token gathered before.
 	err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
 		var err error
@@ -1120,22 +1560,74 @@ func (ac *AdminClient) isConsistent(ctx context.Context, tableName, token string
 	return resp.GetConsistent(), nil
 }
 
+// WaitForReplicationOption configures WaitForReplication's polling and
+// consistency semantics.
+type WaitForReplicationOption interface {
+	apply(*waitForReplicationConfig)
+}
+
+type waitForReplicationConfig struct {
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	deadline        time.Time
+	mode            ConsistencyMode
+}
+
+type pollIntervalOption struct{ interval, max time.Duration }
+
+func (o pollIntervalOption) apply(c *waitForReplicationConfig) {
+	c.pollInterval = o.interval
+	c.maxPollInterval = o.max
+}
+
+// WithPollInterval sets how often WaitForReplication calls CheckConsistency,
+// starting at interval and doubling on every consecutive inconsistent
+// result up to max. A zero max disables backoff, polling at a constant
+// interval (WaitForReplication's default: a constant 10 seconds).
+func WithPollInterval(interval, max time.Duration) WaitForReplicationOption {
+	return pollIntervalOption{interval: interval, max: max}
+}
+
+type deadlineOption time.Time
+
+func (o deadlineOption) apply(c *waitForReplicationConfig) { c.deadline = time.Time(o) }
+
+// WithDeadline bounds how long WaitForReplication polls, independently of
+// ctx's own deadline; WaitForReplication returns once whichever deadline is
+// sooner elapses.
+func WithDeadline(deadline time.Time) WaitForReplicationOption {
+	return deadlineOption(deadline)
+}
+
+type waitConsistencyModeOption ConsistencyMode
+
+func (o waitConsistencyModeOption) apply(c *waitForReplicationConfig) { c.mode = ConsistencyMode(o) }
+
+// WithWaitConsistencyMode is WithConsistencyMode for WaitForReplication.
+func WithWaitConsistencyMode(mode ConsistencyMode) WaitForReplicationOption {
+	return waitConsistencyModeOption(mode)
+}
+
 // WaitForReplication waits until all the writes committed before the call started have been propagated to all the clusters in the instance via replication.
-func (ac *AdminClient) WaitForReplication(ctx context.Context, table string) error {
-	ctx = mergeOutgoingMetadata(ctx, ac.md)
-	// Get the token.
-	prefix := ac.instancePrefix()
-	tableName := prefix + "/tables/" + table
-	token, err := ac.getConsistencyToken(ctx, tableName)
+func (ac *AdminClient) WaitForReplication(ctx context.Context, table string, opts ...WaitForReplicationOption) error {
+	conf := waitForReplicationConfig{pollInterval: 10 * time.Second}
+	for _, o := range opts {
+		o.apply(&conf)
+	}
+	if !conf.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, conf.deadline)
+		defer cancel()
+	}
+
+	token, err := ac.GenerateConsistencyToken(ctx, table)
 	if err != nil {
 		return err
 	}
 
-	// Periodically check if the token is consistent.
-	timer := time.NewTicker(time.Second * 10)
-	defer timer.Stop()
+	interval := conf.pollInterval
 	for {
-		consistent, err := ac.isConsistent(ctx, tableName, token)
+		consistent, err := ac.CheckConsistency(ctx, table, token, WithConsistencyMode(conf.mode))
 		if err != nil {
 			return err
 		}
@@ -1146,7 +1638,13 @@ func (ac *AdminClient) WaitForReplication(ctx context.Context, table string) err
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-timer.C:
+		case <-time.After(interval):
+		}
+		if conf.maxPollInterval > 0 {
+			interval *= 2
+			if interval > conf.maxPollInterval {
+				interval = conf.maxPollInterval
+			}
 		}
 	}
 }
@@ -1162,11 +1660,18 @@ func (ac *AdminClient) BackupIAM(cluster, backup string) *iam.Handle {
 	return iam.InternalNewHandleGRPCClient(ac.tClient, ac.backupPath(cluster, ac.instance, backup))
 }
 
-// AuthorizedViewIAM creates an IAM Handle specific to a given Table and AuthorizedView.
+// AuthorizedViewIAM creates an IAM Handle specific to a given Table and
+// AuthorizedView. As with TableIAM, call Policy, SetPolicy, and
+// TestPermissions on the returned Handle to manage its bindings.
 func (ac *AdminClient) AuthorizedViewIAM(table, authorizedView string) *iam.Handle {
 	return iam.InternalNewHandleGRPCClient(ac.tClient, ac.authorizedViewPath(table, authorizedView))
 }
 
+// SchemaBundleIAM creates an IAM Handle specific to a given Table and SchemaBundle.
+func (ac *AdminClient) SchemaBundleIAM(table, schemaBundle string) *iam.Handle {
+	return iam.InternalNewHandleGRPCClient(ac.tClient, ac.schemaBundlePath(table, schemaBundle))
+}
+
 // UNIVERSE_DOMAIN placeholder is replaced by the UniverseDomain from DialSettings while creating GRPC connection/dial pool.
 const instanceAdminAddr = "bigtableadmin.UNIVERSE_DOMAIN:443"
 const mtlsInstanceAdminAddr = "bigtableadmin.mtls.googleapis.com:443"
@@ -1182,6 +1687,20 @@ type InstanceAdminClient struct {
 
 	// Metadata to be sent with each request.
 	md metadata.MD
+
+	// maxConcurrentClusterOps caps how many clusters
+	// UpdateInstanceWithClusters updates at once; see
+	// SetMaxConcurrentClusterOps. Zero means 1 (sequential).
+	maxConcurrentClusterOps int
+}
+
+// SetMaxConcurrentClusterOps sets how many of UpdateInstanceWithClusters'
+// per-cluster updates run concurrently, instead of the default of one at
+// a time, for instances with several replicated clusters, where updating
+// them serially is slow. Either way, every cluster is attempted even if
+// another one fails.
+func (iac *InstanceAdminClient) SetMaxConcurrentClusterOps(n int) {
+	iac.maxConcurrentClusterOps = n
 }
 
 // NewInstanceAdminClient creates a new InstanceAdminClient for a given project.
@@ -1304,6 +1823,11 @@ type InstanceWithClustersConfig struct {
 	Clusters                []ClusterConfig
 	InstanceType            InstanceType
 	Labels                  map[string]string
+	// AllowClusterDeletion must be set for UpdateInstanceAndSyncClusters (or
+	// an UpdateInstancePlan's Apply) to delete clusters that are missing
+	// from Clusters but present in the instance. Without it, Apply fails
+	// rather than risk deleting a cluster the caller simply forgot to list.
+	AllowClusterDeletion bool
 }
 
 var instanceNameRegexp = regexp.MustCompile(`^projects/([^/]+)/instances/([a-z][-a-z0-9]*)$`)
@@ -1414,9 +1938,15 @@ func (iac *InstanceAdminClient) updateInstance(ctx context.Context, conf *Instan
 //     AutoscalingConfig, which if set will be updated. If both are provided,
 //     AutoscalingConfig takes precedence.
 //
-// This method may return an error after partially succeeding, for example if the instance is updated
-// but a cluster update fails. If an error is returned, InstanceInfo and Clusters may be called to
-// determine the current state.
+// This method attempts every cluster update in conf.Clusters even if one
+// fails: a failure is reported as a *MultiClusterUpdateError, whose
+// Failed field maps each failed cluster's ID to its error, instead of
+// aborting the rest and describing only the first failure in a wrapped
+// error string. If an error is returned, InstanceInfo and Clusters may be
+// called to determine the current state.
+//
+// Cluster updates run concurrently, up to SetMaxConcurrentClusterOps'
+// limit (or sequentially if that's never been called).
 func (iac *InstanceAdminClient) UpdateInstanceWithClusters(ctx context.Context, conf *InstanceWithClustersConfig) error {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 
@@ -1431,27 +1961,85 @@ func (iac *InstanceAdminClient) UpdateInstanceWithClusters(ctx context.Context,
 		return err
 	}
 
-	// Update any clusters
+	concurrency := iac.maxConcurrentClusterOps
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mu sync.Mutex
+	failed := make(map[string]error)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
 	for _, cluster := range conf.Clusters {
-		var clusterErr error
-		if cluster.AutoscalingConfig != nil {
-			clusterErr = iac.SetAutoscaling(ctx, conf.InstanceID, cluster.ClusterID, *cluster.AutoscalingConfig)
-		} else if cluster.NumNodes > 0 {
-			clusterErr = iac.UpdateCluster(ctx, conf.InstanceID, cluster.ClusterID, cluster.NumNodes)
-		}
-		if clusterErr != nil {
-			if updatedInstance {
-				// We updated the instance, so note that in the error message.
-				return fmt.Errorf("UpdateCluster %q failed %w; however UpdateInstance succeeded",
-					cluster.ClusterID, clusterErr)
-			}
-			return clusterErr
+		cluster := cluster
+		if cluster.AutoscalingConfig == nil && cluster.NumNodes <= 0 {
+			// Matches the pre-existing no-op rule: a cluster with
+			// neither a valid NumNodes nor an AutoscalingConfig isn't
+			// updated.
+			continue
 		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var clusterErr error
+			if cluster.AutoscalingConfig != nil {
+				clusterErr = iac.SetAutoscaling(ctx, conf.InstanceID, cluster.ClusterID, *cluster.AutoscalingConfig)
+			} else {
+				clusterErr = iac.UpdateCluster(ctx, conf.InstanceID, cluster.ClusterID, cluster.NumNodes)
+			}
+			if clusterErr != nil {
+				mu.Lock()
+				failed[cluster.ClusterID] = clusterErr
+				mu.Unlock()
+			}
+		}()
 	}
+	wg.Wait()
 
+	if len(failed) > 0 {
+		return &MultiClusterUpdateError{InstanceUpdated: updatedInstance, Failed: failed}
+	}
 	return nil
 }
 
+// MultiClusterUpdateError reports that UpdateInstanceWithClusters
+// attempted an update on every one of its clusters even though one or
+// more failed, so a caller can retry just the clusters in Failed instead
+// of re-parsing an error string to find them.
+type MultiClusterUpdateError struct {
+	// InstanceUpdated reports whether the instance-level update
+	// (DisplayName/InstanceType/Labels) succeeded before cluster updates
+	// started.
+	InstanceUpdated bool
+	// Failed maps each cluster ID whose update failed to its error.
+	Failed map[string]error
+}
+
+func (e *MultiClusterUpdateError) Error() string {
+	ids := make([]string, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("%s: %v", id, e.Failed[id])
+	}
+	return fmt.Sprintf("bigtable: %d cluster update(s) failed: %s", len(e.Failed), strings.Join(parts, "; "))
+}
+
+// Unwrap supports errors.Is/errors.As over every failed cluster's error.
+func (e *MultiClusterUpdateError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 // DeleteInstance deletes an instance from the project.
 func (iac *InstanceAdminClient) DeleteInstance(ctx context.Context, instanceID string) error {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
@@ -1704,6 +2292,9 @@ type ClusterInfo struct {
 // CreateCluster creates a new cluster in an instance.
 // This method will return when the cluster has been created or when an error occurs.
 func (iac *InstanceAdminClient) CreateCluster(ctx context.Context, conf *ClusterConfig) error {
+	if err := conf.Validate(); err != nil {
+		return err
+	}
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 
 	req := &btapb.CreateClusterRequest{
@@ -1730,7 +2321,17 @@ func (iac *InstanceAdminClient) DeleteCluster(ctx context.Context, instanceID, c
 
 // SetAutoscaling enables autoscaling on a cluster. To remove autoscaling, use
 // UpdateCluster. See AutoscalingConfig documentation for details.
+//
+// SetAutoscaling validates conf's MinNodes/MaxNodes/CPUTargetPercent up
+// front, the same checks ClusterConfig.Validate runs; it can't also check
+// StorageUtilizationPerNode's SSD/HDD band or NodeScalingFactor2X's
+// even-node-count rule, since this method's signature doesn't carry the
+// cluster's StorageType or NodeScalingFactor. Use ClusterConfig.Validate
+// directly if those matter.
 func (iac *InstanceAdminClient) SetAutoscaling(ctx context.Context, instanceID, clusterID string, conf AutoscalingConfig) error {
+	if err := conf.validateBasic(); err != nil {
+		return err
+	}
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 	cluster := &btapb.Cluster{
 		Name: "projects/" + iac.project + "/instances/" + instanceID + "/clusters/" + clusterID,
@@ -1755,7 +2356,15 @@ func (iac *InstanceAdminClient) SetAutoscaling(ctx context.Context, instanceID,
 // UpdateCluster updates attributes of a cluster. If Autoscaling is configured
 // for the cluster, it will be removed and replaced by the static number of
 // serve nodes specified.
+//
+// UpdateCluster only checks that serveNodes is positive; unlike
+// ClusterConfig.Validate, it can't check serveNodes against
+// NodeScalingFactor2X's even-node-count rule, since this method's
+// signature doesn't carry the cluster's NodeScalingFactor.
 func (iac *InstanceAdminClient) UpdateCluster(ctx context.Context, instanceID, clusterID string, serveNodes int32) error {
+	if serveNodes <= 0 {
+		return &ClusterConfigError{Field: "serveNodes", Value: serveNodes, Reason: "must be positive"}
+	}
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 	cluster := &btapb.Cluster{
 		Name:       "projects/" + iac.project + "/instances/" + instanceID + "/clusters/" + clusterID,
@@ -1889,6 +2498,20 @@ func (iac *InstanceAdminClient) InstanceIAM(instanceID string) *iam.Handle {
 	return iam.InternalNewHandleGRPCClient(iac.iClient, "projects/"+iac.project+"/instances/"+instanceID)
 }
 
+// LogicalViewIAM creates an IAM Handle specific to a given Instance and
+// LogicalView. As with InstanceIAM, call Policy, SetPolicy, and
+// TestPermissions on the returned Handle to manage its bindings.
+func (iac *InstanceAdminClient) LogicalViewIAM(instanceID, logicalViewID string) *iam.Handle {
+	return iam.InternalNewHandleGRPCClient(iac.iClient, logicalViewPath(iac.project, instanceID, logicalViewID))
+}
+
+// MaterializedViewIAM creates an IAM Handle specific to a given Instance
+// and MaterializedView. As with InstanceIAM, call Policy, SetPolicy, and
+// TestPermissions on the returned Handle to manage its bindings.
+func (iac *InstanceAdminClient) MaterializedViewIAM(instanceID, materializedViewID string) *iam.Handle {
+	return iam.InternalNewHandleGRPCClient(iac.iClient, materializedlViewPath(iac.project, instanceID, materializedViewID))
+}
+
 // Routing policies.
 const (
 	// Deprecated: Use MultiClusterRoutingUseAnyConfig instead.
@@ -1967,13 +2590,17 @@ func setRoutingPolicy(appProfile *btapb.AppProfile, rpc RoutingPolicyConfig, rou
 				},
 			}
 			if cfg.Affinity != nil {
-				switch cfg.Affinity.(type) {
+				switch a := cfg.Affinity.(type) {
 				case *RowAffinity:
 					appProfile.GetMultiClusterRoutingUseAny().Affinity = &btapb.AppProfile_MultiClusterRoutingUseAny_RowAffinity_{
 						RowAffinity: &btapb.AppProfile_MultiClusterRoutingUseAny_RowAffinity{},
 					}
+				case *RowAffinityWithSalt:
+					return fmt.Errorf("bigtable: RowAffinityWithSalt %q: the current proto surface has no salt field for row affinity; use RowAffinity instead", a.Salt)
+				case *ClusterWeightedAffinity:
+					return fmt.Errorf("bigtable: ClusterWeightedAffinity %v: the current proto surface has no weighting field for multi-cluster routing; omit Affinity instead", a.Weights)
 				default:
-					return errors.New("bigtable: invalid affinity in MultiClusterRoutingUseAnyConfig")
+					return fmt.Errorf("bigtable: unknown affinity type in MultiClusterRoutingUseAnyConfig: %T", a)
 				}
 			}
 		case *SingleClusterRoutingConfig:
@@ -2029,6 +2656,12 @@ type ProfileAttrsToUpdate struct {
 	// If set, updates the isolation options.
 	Isolation AppProfileIsolation
 
+	// If set, stages the update through a shadow app profile via
+	// RolloutAppProfile instead of flipping RoutingConfig/Isolation on
+	// the primary profile atomically. Ignored by UpdateAppProfile
+	// itself; only RolloutAppProfile consults it.
+	Rollout *RolloutConfig
+
 	// If set, updates the routing policy.
 	// Deprecated: Use RoutingConfig instead.
 	RoutingPolicy optional.String
@@ -2052,7 +2685,7 @@ func (p *ProfileAttrsToUpdate) GetFieldMaskPath() []string {
 	}
 
 	if p.RoutingConfig != nil {
-		path = append(path, p.RoutingConfig.getFieldMaskPath())
+		path = append(path, p.RoutingConfig.getFieldMaskPaths()...)
 	} else if p.RoutingPolicy != nil {
 		path = append(path, optional.ToString(p.RoutingPolicy))
 	}
@@ -2066,7 +2699,13 @@ func (p *ProfileAttrsToUpdate) GetFieldMaskPath() []string {
 // RoutingPolicyConfig represents the configuration for a specific routing policy.
 type RoutingPolicyConfig interface {
 	isRoutingPolicyConfig()
-	getFieldMaskPath() string
+	// getFieldMaskPaths returns the UpdateAppProfileRequest field mask
+	// paths an update to this config should set, as narrow as the config
+	// can tell: a RoutingPolicyConfig that only changes part of a nested
+	// message (e.g. MultiClusterRoutingUseAnyConfig.Affinity alone)
+	// returns just that nested path, so UpdateAppProfile doesn't clobber
+	// sibling fields (e.g. ClusterIDs) it wasn't asked to touch.
+	getFieldMaskPaths() []string
 }
 
 // SingleClusterRoutingConfig is a policy that unconditionally routes all
@@ -2081,8 +2720,10 @@ type SingleClusterRoutingConfig struct {
 	AllowTransactionalWrites bool
 }
 
-func (*SingleClusterRoutingConfig) isRoutingPolicyConfig()   {}
-func (*SingleClusterRoutingConfig) getFieldMaskPath() string { return "single_cluster_routing" }
+func (*SingleClusterRoutingConfig) isRoutingPolicyConfig() {}
+func (*SingleClusterRoutingConfig) getFieldMaskPaths() []string {
+	return []string{"single_cluster_routing"}
+}
 
 // MultiClusterRoutingUseAnyConfig is a policy whererin read/write requests are
 // routed to the nearest cluster in the instance, and
@@ -2102,8 +2743,23 @@ type MultiClusterRoutingUseAnyConfig struct {
 }
 
 func (*MultiClusterRoutingUseAnyConfig) isRoutingPolicyConfig() {}
-func (*MultiClusterRoutingUseAnyConfig) getFieldMaskPath() string {
-	return "multi_cluster_routing_use_any"
+
+// getFieldMaskPaths reports the narrowest field mask paths that cover
+// what c changes: cluster_ids and/or row_affinity for whichever of
+// ClusterIDs and Affinity are set, or, if neither is set, the whole
+// multi_cluster_routing_use_any message.
+func (c *MultiClusterRoutingUseAnyConfig) getFieldMaskPaths() []string {
+	if c.ClusterIDs == nil && c.Affinity == nil {
+		return []string{"multi_cluster_routing_use_any"}
+	}
+	var paths []string
+	if c.ClusterIDs != nil {
+		paths = append(paths, "multi_cluster_routing_use_any.cluster_ids")
+	}
+	if c.Affinity != nil {
+		paths = append(paths, "multi_cluster_routing_use_any.row_affinity")
+	}
+	return paths
 }
 
 // MultiClusterRoutingUseAnyAffinity represents the configuration for a specific affinity strategy.
@@ -2119,9 +2775,47 @@ type RowAffinity struct{}
 
 func (*RowAffinity) isMultiClusterRoutingUseAnyAffinity() {}
 
-// AppProfileIsolation represents the configuration for a specific traffic isolation policy.
-type AppProfileIsolation interface {
-	isAppProfileIsolation()
+// RowAffinityWithSalt is row-based affinity (see RowAffinity) seasoned
+// with Salt, intended to let two app profiles that would otherwise
+// assign the same row key to the same cluster instead shuffle
+// independently across their cluster pools.
+//
+// The current AppProfile_MultiClusterRoutingUseAny_RowAffinity message
+// has no field for a salt: Cloud Bigtable's row-affinity hashing isn't
+// caller-seedable. setRoutingPolicy rejects RowAffinityWithSalt with a
+// clear error rather than silently applying plain RowAffinity and
+// dropping Salt, so callers find out at config time rather than
+// discovering it's a no-op in production. It's defined so that a future
+// proto surface adding this field only needs setRoutingPolicy updated,
+// not every caller.
+type RowAffinityWithSalt struct {
+	Salt string
+}
+
+func (*RowAffinityWithSalt) isMultiClusterRoutingUseAnyAffinity() {}
+
+// ClusterWeightedAffinity weights how routable requests without a
+// pinning row affinity should be distributed across ClusterIDs, as a
+// ratio (e.g. {"cluster-a": 3, "cluster-b": 1} sends roughly 3x as much
+// traffic to cluster-a).
+//
+// The current AppProfile_MultiClusterRoutingUseAny message has no
+// weighting field: Cloud Bigtable doesn't expose caller-controlled
+// traffic splitting within a multi-cluster routing policy.
+// setRoutingPolicy rejects ClusterWeightedAffinity with a clear error
+// rather than silently ignoring Weights, so callers find out at config
+// time rather than discovering it's a no-op in production. It's defined
+// so that a future proto surface adding this field only needs
+// setRoutingPolicy updated, not every caller.
+type ClusterWeightedAffinity struct {
+	Weights map[string]int32
+}
+
+func (*ClusterWeightedAffinity) isMultiClusterRoutingUseAnyAffinity() {}
+
+// AppProfileIsolation represents the configuration for a specific traffic isolation policy.
+type AppProfileIsolation interface {
+	isAppProfileIsolation()
 	getFieldMaskPath() string
 }
 
@@ -2193,6 +2887,22 @@ func (it *ProfileIterator) Next() (*btapb.AppProfile, error) {
 	return item, nil
 }
 
+// Count drains it, returning how many app profiles it yields and
+// discarding them, for a caller that only needs cardinality.
+func (it *ProfileIterator) Count() (int, error) {
+	n := 0
+	for {
+		_, err := it.Next()
+		if err == iterator.Done {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
 // CreateAppProfile creates an app profile within an instance.
 func (iac *InstanceAdminClient) CreateAppProfile(ctx context.Context, profile ProfileConf) (*btapb.AppProfile, error) {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
@@ -2239,7 +2949,79 @@ func (iac *InstanceAdminClient) GetAppProfile(ctx context.Context, instanceID, n
 }
 
 // ListAppProfiles lists information about app profiles in an instance.
+//
+// ListAppProfiles is ListAppProfilesWithOptions with no options.
 func (iac *InstanceAdminClient) ListAppProfiles(ctx context.Context, instanceID string) *ProfileIterator {
+	return iac.ListAppProfilesWithOptions(ctx, instanceID)
+}
+
+// ListAppProfilesOption configures ListAppProfilesWithOptions's filter
+// and sort order.
+//
+// Unlike ListBackupsOption, these compose into filtering and sorting
+// ListAppProfilesWithOptions performs client-side, not a server-side
+// filter/order_by request field: the current ListAppProfilesRequest
+// proto has neither. So, unlike BackupsWithOptions, a filtered
+// ListAppProfilesWithOptions still streams every app profile in the
+// instance from the server; it only saves the caller from writing the
+// same filter/sort loop over the iterator themselves.
+type ListAppProfilesOption interface {
+	apply(*listAppProfilesConfig)
+}
+
+type listAppProfilesConfig struct {
+	match func(*btapb.AppProfile) bool
+	less  func(a, b *btapb.AppProfile) bool
+}
+
+type listAppProfilesOptionFunc func(*listAppProfilesConfig)
+
+func (f listAppProfilesOptionFunc) apply(c *listAppProfilesConfig) { f(c) }
+
+// FilterAppProfilesByClusterID restricts ListAppProfilesWithOptions to
+// app profiles that can route to clusterID: one with SingleClusterRouting
+// naming it, or a MultiClusterRoutingUseAny whose ClusterIds names it or
+// (meaning every cluster is eligible) lists none at all.
+func FilterAppProfilesByClusterID(clusterID string) ListAppProfilesOption {
+	return listAppProfilesOptionFunc(func(c *listAppProfilesConfig) {
+		c.match = func(ap *btapb.AppProfile) bool {
+			if scr := ap.GetSingleClusterRouting(); scr != nil {
+				return scr.ClusterId == clusterID
+			}
+			if mcr := ap.GetMultiClusterRoutingUseAny(); mcr != nil {
+				if len(mcr.ClusterIds) == 0 {
+					return true
+				}
+				for _, id := range mcr.ClusterIds {
+					if id == clusterID {
+						return true
+					}
+				}
+			}
+			return false
+		}
+	})
+}
+
+// OrderAppProfilesByID sorts ListAppProfilesWithOptions's results by
+// resource name.
+func OrderAppProfilesByID() ListAppProfilesOption {
+	return listAppProfilesOptionFunc(func(c *listAppProfilesConfig) {
+		c.less = func(a, b *btapb.AppProfile) bool { return a.Name < b.Name }
+	})
+}
+
+// ListAppProfilesWithOptions is ListAppProfiles, but lets the caller
+// compose a filter (FilterAppProfilesByClusterID) and sort order
+// (OrderAppProfilesByID) instead of writing the same loop over the
+// iterator's results themselves. See ListAppProfilesOption's doc comment
+// for why, unlike BackupsWithOptions, this filtering happens client-side.
+func (iac *InstanceAdminClient) ListAppProfilesWithOptions(ctx context.Context, instanceID string, opts ...ListAppProfilesOption) *ProfileIterator {
+	cfg := listAppProfilesConfig{}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 	listRequest := &btapb.ListAppProfilesRequest{
 		Parent: "projects/" + iac.project + "/instances/" + instanceID,
@@ -2248,18 +3030,34 @@ func (iac *InstanceAdminClient) ListAppProfiles(ctx context.Context, instanceID
 	pit := &ProfileIterator{}
 	fetch := func(pageSize int, pageToken string) (string, error) {
 		listRequest.PageToken = pageToken
-		var profileRes *btapb.ListAppProfilesResponse
-		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
-			var err error
-			profileRes, err = iac.iClient.ListAppProfiles(ctx, listRequest)
-			return err
-		}, adminRetryOptions...)
-		if err != nil {
-			return "", err
-		}
+		for {
+			var profileRes *btapb.ListAppProfilesResponse
+			err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+				var err error
+				profileRes, err = iac.iClient.ListAppProfiles(ctx, listRequest)
+				return err
+			}, adminRetryOptions...)
+			if err != nil {
+				return "", err
+			}
 
-		pit.items = append(pit.items, profileRes.AppProfiles...)
-		return profileRes.NextPageToken, nil
+			for _, ap := range profileRes.AppProfiles {
+				if cfg.match != nil && !cfg.match(ap) {
+					continue
+				}
+				pit.items = append(pit.items, ap)
+			}
+			if cfg.less == nil {
+				return profileRes.NextPageToken, nil
+			}
+			// A sort order covers every page, so gather them all first.
+			if profileRes.NextPageToken != "" {
+				listRequest.PageToken = profileRes.NextPageToken
+				continue
+			}
+			sort.Slice(pit.items, func(i, j int) bool { return cfg.less(pit.items[i], pit.items[j]) })
+			return "", nil
+		}
 	}
 
 	bufLen := func() int { return len(pit.items) }
@@ -2272,6 +3070,59 @@ func (iac *InstanceAdminClient) ListAppProfiles(ctx context.Context, instanceID
 // UpdateAppProfile updates an app profile within an instance.
 // updateAttrs should be set. If unset, all fields will be replaced.
 func (iac *InstanceAdminClient) UpdateAppProfile(ctx context.Context, instanceID, profileID string, updateAttrs ProfileAttrsToUpdate) error {
+	op, err := iac.UpdateAppProfileOperation(ctx, instanceID, profileID, updateAttrs)
+	if err != nil {
+		return err
+	}
+	return op.Wait(ctx)
+}
+
+// AppProfileOperation is a handle to an UpdateAppProfile long-running
+// operation in progress, returned by
+// InstanceAdminClient.UpdateAppProfileOperation.
+type AppProfileOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// InstanceAdminClient.AppProfileOperationByName to reattach to later.
+func (o *AppProfileOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *AppProfileOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *AppProfileOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *AppProfileOperation) Poll(ctx context.Context) (bool, error) {
+	return o.op.Poll(ctx, nil)
+}
+
+// Wait blocks until the operation finishes.
+func (o *AppProfileOperation) Wait(ctx context.Context) error {
+	return o.op.Wait(ctx, nil)
+}
+
+// AppProfileOperationByName returns an AppProfileOperation handle for the
+// long-running operation named name (as previously reported by another
+// AppProfileOperation's Name), so a process that crashed mid-update can
+// reattach to it on restart instead of losing track of it.
+func (iac *InstanceAdminClient) AppProfileOperationByName(name string) *AppProfileOperation {
+	return &AppProfileOperation{op: longrunning.InternalNewOperation(iac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// UpdateAppProfileOperation is like UpdateAppProfile, but returns an
+// AppProfileOperation handle instead of blocking until the update
+// finishes.
+func (iac *InstanceAdminClient) UpdateAppProfileOperation(ctx context.Context, instanceID, profileID string, updateAttrs ProfileAttrsToUpdate) (*AppProfileOperation, error) {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 
 	profile := &btapb.AppProfile{
@@ -2285,12 +3136,12 @@ func (iac *InstanceAdminClient) UpdateAppProfile(ctx context.Context, instanceID
 	err := setRoutingPolicy(profile, updateAttrs.RoutingConfig, updateAttrs.RoutingPolicy,
 		updateAttrs.ClusterID, updateAttrs.AllowTransactionalWrites, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = setIsolation(profile, updateAttrs.Isolation)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	patchRequest := &btapb.UpdateAppProfileRequest{
@@ -2302,11 +3153,10 @@ func (iac *InstanceAdminClient) UpdateAppProfile(ctx context.Context, instanceID
 	}
 	updateRequest, err := iac.iClient.UpdateAppProfile(ctx, patchRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return longrunning.InternalNewOperation(iac.lroClient, updateRequest).Wait(ctx, nil)
-
+	return &AppProfileOperation{op: longrunning.InternalNewOperation(iac.lroClient, updateRequest)}, nil
 }
 
 // DeleteAppProfile deletes an app profile from an instance.
@@ -2342,59 +3192,81 @@ func max(x, y int) int {
 	return y
 }
 
-// UpdateInstanceAndSyncClusters updates an instance and its clusters, and will synchronize the
-// clusters in the instance with the provided clusters, creating and deleting them as necessary.
-// The provided InstanceWithClustersConfig is used as follows:
-//   - InstanceID is required
-//   - DisplayName and InstanceType are updated only if they are not empty
-//   - ClusterID is required for any provided cluster
-//   - Any cluster present in conf.Clusters but not part of the instance will be created using CreateCluster
-//     and the given ClusterConfig.
-//   - Any cluster missing from conf.Clusters but present in the instance will be removed from the instance
-//     using DeleteCluster.
-//   - Any cluster in conf.Clusters that also exists in the instance will be
-//     updated either to contain the provided number of nodes or to use the
-//     provided autoscaling config. If both the number of nodes and autoscaling
-//     are configured, autoscaling takes precedence. If the number of nodes is zero
-//     and autoscaling is not provided in InstanceWithClustersConfig, the cluster
-//     is not updated.
-//
-// This method may return an error after partially succeeding, for example if the instance is updated
-// but a cluster update fails. If an error is returned, InstanceInfo and Clusters may be called to
-// determine the current state. The return UpdateInstanceResults will describe the work done by the
-// method, whether partial or complete.
-func UpdateInstanceAndSyncClusters(ctx context.Context, iac *InstanceAdminClient, conf *InstanceWithClustersConfig) (*UpdateInstanceResults, error) {
+// UpdateInstancePlan is the set of actions UpdateInstanceAndPlanClusters
+// determined it would take against an instance and its clusters, computed
+// without mutating anything. Call Apply to carry it out.
+type UpdateInstancePlan struct {
+	iac  *InstanceAdminClient
+	conf *InstanceWithClustersConfig
+	// existingClusterCount is the number of clusters the instance has today,
+	// before any of ClustersToCreate/ClustersToDelete are applied.
+	existingClusterCount int
+
+	// InstanceWillUpdate reports whether applying the plan will call
+	// PartialUpdateInstance, i.e. whether conf's DisplayName, InstanceType,
+	// or Labels differ from their zero values.
+	InstanceWillUpdate bool
+	// ClustersToCreate are the clusters in conf.Clusters with no matching
+	// cluster in the instance today, in the resolved form CreateCluster
+	// would receive (InstanceID already filled in).
+	ClustersToCreate []ClusterConfig
+	// ClustersToUpdate are the clusters in conf.Clusters that already exist
+	// in the instance and specify a NumNodes or AutoscalingConfig to apply.
+	ClustersToUpdate []ClusterConfig
+	// ClustersToDelete are the existing clusters in the instance with no
+	// matching entry in conf.Clusters.
+	ClustersToDelete []string
+}
+
+func (p *UpdateInstancePlan) String() string {
+	var toCreate, toUpdate []string
+	for _, c := range p.ClustersToCreate {
+		toCreate = append(toCreate, c.ClusterID)
+	}
+	for _, c := range p.ClustersToUpdate {
+		toUpdate = append(toUpdate, c.ClusterID)
+	}
+	return fmt.Sprintf("Instance will update? %v Clusters to create:%v Clusters to update:%v Clusters to delete:%v",
+		p.InstanceWillUpdate, toCreate, toUpdate, p.ClustersToDelete)
+}
+
+// UpdateInstanceAndPlanClusters computes the exact set of actions
+// UpdateInstanceAndSyncClusters would take to reconcile an instance and its
+// clusters with conf, without taking any of them. See
+// UpdateInstanceAndSyncClusters for how conf is interpreted. Call Apply on
+// the returned plan to carry it out.
+func UpdateInstanceAndPlanClusters(ctx context.Context, iac *InstanceAdminClient, conf *InstanceWithClustersConfig) (*UpdateInstancePlan, error) {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 
+	if conf.InstanceID == "" {
+		return nil, errors.New("InstanceID is required")
+	}
+
 	// First fetch the existing clusters so we know what to remove, add or update.
 	existingClusters, err := iac.Clusters(ctx, conf.InstanceID)
 	if err != nil {
 		return nil, err
 	}
 
-	updatedInstance, err := iac.updateInstance(ctx, conf)
-	if err != nil {
-		return nil, err
+	plan := &UpdateInstancePlan{
+		iac:                  iac,
+		conf:                 conf,
+		existingClusterCount: len(existingClusters),
+		InstanceWillUpdate: conf.DisplayName != "" ||
+			btapb.Instance_Type(conf.InstanceType) != btapb.Instance_TYPE_UNSPECIFIED ||
+			conf.Labels != nil,
 	}
 
-	results := &UpdateInstanceResults{InstanceUpdated: updatedInstance}
-
 	existingClusterNames := make(map[string]bool)
 	for _, cluster := range existingClusters {
 		existingClusterNames[cluster.Name] = true
 	}
 
-	// Synchronize clusters that were passed in with the existing clusters in the instance.
-	// First update any cluster we encounter that already exists in the instance.
-	// Collect the clusters that we will create and delete so that we can minimize disruption
-	// of the instance.
-	clustersToCreate := list.New()
-	clustersToDelete := list.New()
 	for _, cluster := range conf.Clusters {
 		_, clusterExists := existingClusterNames[cluster.ClusterID]
 		if !clusterExists {
-			// The cluster doesn't exist yet, so we must create it.
-			clustersToCreate.PushBack(cluster)
+			cluster.InstanceID = conf.InstanceID
+			plan.ClustersToCreate = append(plan.ClustersToCreate, cluster)
 			continue
 		}
 		delete(existingClusterNames, cluster.ClusterID)
@@ -2404,27 +3276,155 @@ func UpdateInstanceAndSyncClusters(ctx context.Context, iac *InstanceAdminClient
 			// or a valid autoscaling config.
 			continue
 		}
+		plan.ClustersToUpdate = append(plan.ClustersToUpdate, cluster)
+	}
 
-		// We update the clusters autoscaling config, or its number of serve
-		// nodes.
-		var updateErr error
-		if cluster.AutoscalingConfig != nil {
-			updateErr = iac.SetAutoscaling(ctx, conf.InstanceID, cluster.ClusterID,
-				*cluster.AutoscalingConfig)
-		} else {
-			updateErr = iac.UpdateCluster(ctx, conf.InstanceID, cluster.ClusterID,
-				cluster.NumNodes)
+	// Any cluster left in existingClusterNames was NOT in the given config and should be deleted.
+	for clusterToDelete := range existingClusterNames {
+		plan.ClustersToDelete = append(plan.ClustersToDelete, clusterToDelete)
+	}
+
+	return plan, nil
+}
+
+// Apply carries out p: it updates the instance if InstanceWillUpdate, then
+// creates, updates, and deletes clusters as p describes. Deletions and
+// creations are interleaved so that instance capacity is never reduced more
+// than necessary, and the last cluster in an instance is never deleted. If
+// p would delete any cluster and p's InstanceWithClustersConfig doesn't set
+// AllowClusterDeletion, Apply returns an error without taking any action,
+// since a caller who simply forgot to list a cluster would otherwise lose
+// it silently.
+//
+// Apply may return an error after partially succeeding, for example if the
+// instance is updated but a cluster update fails. If an error is returned,
+// InstanceInfo and Clusters may be called to determine the current state.
+// The returned UpdateInstanceResults describes the work done, whether
+// partial or complete.
+//
+// Apply is ApplyWithOptions with no options: it stops at the first failure
+// and runs sequentially. Call ApplyWithOptions directly for a PreStepHook,
+// best-effort execution, or concurrency.
+func (p *UpdateInstancePlan) Apply(ctx context.Context) (*UpdateInstanceResults, error) {
+	return p.ApplyWithOptions(ctx)
+}
+
+// ApplyStep describes one action ApplyWithOptions is about to take against
+// the instance or one of its clusters, passed to a PreStepHook.
+type ApplyStep struct {
+	// Verb is "update-instance", "create", "update", or "delete".
+	// ClusterID is empty when Verb is "update-instance".
+	Verb      string
+	ClusterID string
+}
+
+type applyConfig struct {
+	concurrency int
+	bestEffort  bool
+	preStepHook func(ApplyStep) error
+}
+
+// ApplyOption is a functional option for UpdateInstancePlan.ApplyWithOptions.
+type ApplyOption func(*applyConfig)
+
+// WithApplyConcurrency runs p's cluster creates, updates, and deletes up to
+// n at a time instead of ApplyWithOptions's default of one at a time.
+// Running concurrently means the delete-before-create interleaving Apply
+// otherwise uses to protect instance capacity can't be preserved, so n
+// greater than 1 requires WithBestEffort(true).
+func WithApplyConcurrency(n int) ApplyOption {
+	return func(c *applyConfig) { c.concurrency = n }
+}
+
+// WithBestEffort makes ApplyWithOptions attempt every step in the plan even
+// after one fails, instead of stopping at the first failure. Every error
+// encountered is aggregated with errors.Join into the returned error.
+func WithBestEffort(bestEffort bool) ApplyOption {
+	return func(c *applyConfig) { c.bestEffort = bestEffort }
+}
+
+// WithPreStepHook registers a callback ApplyWithOptions invokes before each
+// step, so a caller can log or gate destructive ones (for example, refusing
+// a "delete" ApplyStep). An error returned from hook skips that step and is
+// folded into the returned error like any other step failure.
+func WithPreStepHook(hook func(ApplyStep) error) ApplyOption {
+	return func(c *applyConfig) { c.preStepHook = hook }
+}
+
+// ApplyWithOptions is Apply, configurable for infrastructure-as-code style
+// callers that need to see or gate individual steps rather than treat the
+// plan as all-or-nothing. With no options it behaves exactly like Apply.
+func (p *UpdateInstancePlan) ApplyWithOptions(ctx context.Context, opts ...ApplyOption) (*UpdateInstanceResults, error) {
+	cfg := applyConfig{concurrency: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.concurrency > 1 && !cfg.bestEffort {
+		return nil, errors.New("bigtable: WithApplyConcurrency greater than 1 requires WithBestEffort(true)")
+	}
+	if len(p.ClustersToDelete) > 0 && !p.conf.AllowClusterDeletion {
+		return nil, fmt.Errorf("bigtable: plan deletes clusters %v; set InstanceWithClustersConfig.AllowClusterDeletion to allow this", p.ClustersToDelete)
+	}
+
+	ctx = mergeOutgoingMetadata(ctx, p.iac.md)
+	iac := p.iac
+	conf := p.conf
+
+	runStep := func(step ApplyStep, fn func() error) error {
+		if cfg.preStepHook != nil {
+			if err := cfg.preStepHook(step); err != nil {
+				return fmt.Errorf("PreStepHook rejected %s %s: %w", step.Verb, step.ClusterID, err)
+			}
 		}
-		if updateErr != nil {
-			return results, fmt.Errorf("UpdateCluster %q failed %w; Progress: %v",
-				cluster.ClusterID, updateErr, results)
+		return fn()
+	}
+
+	results := &UpdateInstanceResults{}
+	updateErr := runStep(ApplyStep{Verb: "update-instance"}, func() error {
+		updated, err := iac.updateInstance(ctx, conf)
+		results.InstanceUpdated = updated
+		return err
+	})
+	if updateErr != nil {
+		return results, updateErr
+	}
+
+	if cfg.concurrency <= 1 {
+		return p.applySequential(ctx, results, runStep, cfg.bestEffort)
+	}
+	return p.applyConcurrent(ctx, results, runStep, cfg.concurrency)
+}
+
+// applySequential runs p's cluster updates, then its interleaved
+// create/delete steps, one at a time and in the same order Apply has
+// always used, continuing past failures only when bestEffort is set.
+func (p *UpdateInstancePlan) applySequential(ctx context.Context, results *UpdateInstanceResults, runStep func(ApplyStep, func() error) error, bestEffort bool) (*UpdateInstanceResults, error) {
+	iac := p.iac
+	conf := p.conf
+	var errs []error
+	fail := func(err error) error {
+		errs = append(errs, err)
+		if !bestEffort {
+			return errors.Join(errs...)
 		}
-		results.UpdatedClusters = append(results.UpdatedClusters, cluster.ClusterID)
+		return nil
 	}
 
-	// Any cluster left in existingClusterNames was NOT in the given config and should be deleted.
-	for clusterToDelete := range existingClusterNames {
-		clustersToDelete.PushBack(clusterToDelete)
+	for _, cluster := range p.ClustersToUpdate {
+		cluster := cluster
+		err := runStep(ApplyStep{Verb: "update", ClusterID: cluster.ClusterID}, func() error {
+			if cluster.AutoscalingConfig != nil {
+				return iac.SetAutoscaling(ctx, conf.InstanceID, cluster.ClusterID, *cluster.AutoscalingConfig)
+			}
+			return iac.UpdateCluster(ctx, conf.InstanceID, cluster.ClusterID, cluster.NumNodes)
+		})
+		if err != nil {
+			if joined := fail(fmt.Errorf("update cluster %q: %w", cluster.ClusterID, err)); joined != nil {
+				return results, joined
+			}
+			continue
+		}
+		results.UpdatedClusters = append(results.UpdatedClusters, cluster.ClusterID)
 	}
 
 	// Now that we have the clusters that we need to create and delete, we do so keeping the following
@@ -2435,7 +3435,16 @@ func UpdateInstanceAndSyncClusters(ctx context.Context, iac *InstanceAdminClient
 	// Note that there is a limit on number of clusters in an instance which we are not aware of here,
 	// so delete a cluster before adding one (as long as there are > 1 clusters left) so that we are
 	// less likely to exceed the maximum number of clusters.
-	numExistingClusters := len(existingClusters)
+	clustersToCreate := list.New()
+	for _, cluster := range p.ClustersToCreate {
+		clustersToCreate.PushBack(cluster)
+	}
+	clustersToDelete := list.New()
+	for _, cluster := range p.ClustersToDelete {
+		clustersToDelete.PushBack(cluster)
+	}
+
+	numExistingClusters := p.existingClusterCount
 	nextCreation := clustersToCreate.Front()
 	nextDeletion := clustersToDelete.Front()
 	for {
@@ -2448,36 +3457,173 @@ func UpdateInstanceAndSyncClusters(ctx context.Context, iac *InstanceAdminClient
 		// If there are no more creations left, always go ahead with the deletion.
 		if (numExistingClusters > 1 && nextDeletion != nil) || nextCreation == nil {
 			clusterToDelete := nextDeletion.Value.(string)
-			err = iac.DeleteCluster(ctx, conf.InstanceID, clusterToDelete)
+			err := runStep(ApplyStep{Verb: "delete", ClusterID: clusterToDelete}, func() error {
+				return iac.DeleteCluster(ctx, conf.InstanceID, clusterToDelete)
+			})
 			if err != nil {
-				return results, fmt.Errorf("DeleteCluster %q failed %w; Progress: %v",
-					clusterToDelete, err, results)
+				if joined := fail(fmt.Errorf("delete cluster %q: %w", clusterToDelete, err)); joined != nil {
+					return results, joined
+				}
+			} else {
+				results.DeletedClusters = append(results.DeletedClusters, clusterToDelete)
+				numExistingClusters--
 			}
-			results.DeletedClusters = append(results.DeletedClusters, clusterToDelete)
-			numExistingClusters--
 			nextDeletion = nextDeletion.Next()
 		}
 
 		// Now create a new cluster if required.
 		if nextCreation != nil {
 			clusterToCreate := nextCreation.Value.(ClusterConfig)
-			// Assume the cluster config is well formed and rely on the underlying call to error out.
-			// Make sure to set the InstanceID, though, since we know what it must be.
-			clusterToCreate.InstanceID = conf.InstanceID
-			err = iac.CreateCluster(ctx, &clusterToCreate)
+			err := runStep(ApplyStep{Verb: "create", ClusterID: clusterToCreate.ClusterID}, func() error {
+				return iac.CreateCluster(ctx, &clusterToCreate)
+			})
 			if err != nil {
-				return results, fmt.Errorf("CreateCluster %v failed %w; Progress: %v",
-					clusterToCreate, err, results)
+				if joined := fail(fmt.Errorf("create cluster %q: %w", clusterToCreate.ClusterID, err)); joined != nil {
+					return results, joined
+				}
+			} else {
+				results.CreatedClusters = append(results.CreatedClusters, clusterToCreate.ClusterID)
+				numExistingClusters++
 			}
-			results.CreatedClusters = append(results.CreatedClusters, clusterToCreate.ClusterID)
-			numExistingClusters++
 			nextCreation = nextCreation.Next()
 		}
 	}
 
+	if len(errs) > 0 {
+		return results, errors.Join(errs...)
+	}
+	return results, nil
+}
+
+// applyConcurrent runs every cluster update, create, and delete in p up to
+// concurrency at a time, always best-effort: every step is attempted and
+// every failure is aggregated with errors.Join, since there's no ordering
+// to stop partway through safely. It doesn't interleave deletes and
+// creates to protect instance capacity the way applySequential does.
+func (p *UpdateInstancePlan) applyConcurrent(ctx context.Context, results *UpdateInstanceResults, runStep func(ApplyStep, func() error) error, concurrency int) (*UpdateInstanceResults, error) {
+	iac := p.iac
+	conf := p.conf
+
+	type step struct {
+		action ApplyStep
+		run    func() error
+	}
+	var steps []step
+	for _, cluster := range p.ClustersToUpdate {
+		cluster := cluster
+		steps = append(steps, step{
+			action: ApplyStep{Verb: "update", ClusterID: cluster.ClusterID},
+			run: func() error {
+				if cluster.AutoscalingConfig != nil {
+					return iac.SetAutoscaling(ctx, conf.InstanceID, cluster.ClusterID, *cluster.AutoscalingConfig)
+				}
+				return iac.UpdateCluster(ctx, conf.InstanceID, cluster.ClusterID, cluster.NumNodes)
+			},
+		})
+	}
+	for _, cluster := range p.ClustersToCreate {
+		cluster := cluster
+		steps = append(steps, step{
+			action: ApplyStep{Verb: "create", ClusterID: cluster.ClusterID},
+			run:    func() error { return iac.CreateCluster(ctx, &cluster) },
+		})
+	}
+	for _, clusterID := range p.ClustersToDelete {
+		clusterID := clusterID
+		steps = append(steps, step{
+			action: ApplyStep{Verb: "delete", ClusterID: clusterID},
+			run:    func() error { return iac.DeleteCluster(ctx, conf.InstanceID, clusterID) },
+		})
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, s := range steps {
+		s := s
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := runStep(s.action, s.run)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s cluster %q: %w", s.action.Verb, s.action.ClusterID, err))
+				return
+			}
+			switch s.action.Verb {
+			case "create":
+				results.CreatedClusters = append(results.CreatedClusters, s.action.ClusterID)
+			case "update":
+				results.UpdatedClusters = append(results.UpdatedClusters, s.action.ClusterID)
+			case "delete":
+				results.DeletedClusters = append(results.DeletedClusters, s.action.ClusterID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, errors.Join(errs...)
+	}
 	return results, nil
 }
 
+// UpdateInstanceAndSyncClusters updates an instance and its clusters, and will synchronize the
+// clusters in the instance with the provided clusters, creating and deleting them as necessary.
+// The provided InstanceWithClustersConfig is used as follows:
+//   - InstanceID is required
+//   - DisplayName and InstanceType are updated only if they are not empty
+//   - ClusterID is required for any provided cluster
+//   - Any cluster present in conf.Clusters but not part of the instance will be created using CreateCluster
+//     and the given ClusterConfig.
+//   - Any cluster missing from conf.Clusters but present in the instance will be removed from the instance
+//     using DeleteCluster, provided conf.AllowClusterDeletion is set; otherwise Apply fails and nothing
+//     is changed.
+//   - Any cluster in conf.Clusters that also exists in the instance will be
+//     updated either to contain the provided number of nodes or to use the
+//     provided autoscaling config. If both the number of nodes and autoscaling
+//     are configured, autoscaling takes precedence. If the number of nodes is zero
+//     and autoscaling is not provided in InstanceWithClustersConfig, the cluster
+//     is not updated.
+//
+// This method may return an error after partially succeeding, for example if the instance is updated
+// but a cluster update fails. If an error is returned, InstanceInfo and Clusters may be called to
+// determine the current state. The return UpdateInstanceResults will describe the work done by the
+// method, whether partial or complete.
+//
+// UpdateInstanceAndSyncClusters is UpdateInstanceAndPlanClusters followed by Apply; call
+// UpdateInstanceAndPlanClusters directly to inspect the planned actions first.
+func UpdateInstanceAndSyncClusters(ctx context.Context, iac *InstanceAdminClient, conf *InstanceWithClustersConfig) (*UpdateInstanceResults, error) {
+	plan, err := UpdateInstanceAndPlanClusters(ctx, iac, conf)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Apply(ctx)
+}
+
+// InstanceSyncPlan is an alias for UpdateInstancePlan, the structured diff
+// PlanInstanceAndClusterSync returns.
+type InstanceSyncPlan = UpdateInstancePlan
+
+// PlanInstanceAndClusterSync is UpdateInstanceAndPlanClusters under the
+// name infrastructure-as-code callers may expect alongside
+// ApplyInstanceAndClusterSyncPlan.
+func PlanInstanceAndClusterSync(ctx context.Context, iac *InstanceAdminClient, conf *InstanceWithClustersConfig) (*InstanceSyncPlan, error) {
+	return UpdateInstanceAndPlanClusters(ctx, iac, conf)
+}
+
+// ApplyInstanceAndClusterSyncPlan is plan.ApplyWithOptions(ctx, opts...)
+// under the name infrastructure-as-code callers may expect alongside
+// PlanInstanceAndClusterSync.
+func ApplyInstanceAndClusterSyncPlan(ctx context.Context, plan *InstanceSyncPlan, opts ...ApplyOption) (*UpdateInstanceResults, error) {
+	return plan.ApplyWithOptions(ctx, opts...)
+}
+
 // RestoreTable creates a table from a backup. The table will be created in the same cluster as the backup.
 // To restore a table to a different instance, see RestoreTableFrom.
 func (ac *AdminClient) RestoreTable(ctx context.Context, table, cluster, backup string) error {
@@ -2488,11 +3634,26 @@ func (ac *AdminClient) RestoreTable(ctx context.Context, table, cluster, backup
 // To restore within the same instance, see RestoreTable.
 // sourceInstance (ex. "my-instance") and sourceCluster (ex. "my-cluster") are the instance and cluster in which the new table will be restored from.
 // tableName (ex. "my-restored-table") will be the name of the newly created table.
-// backupName (ex. "my-backup") is the name of the backup to restore.
+// backup is either a bare backup ID (ex. "my-backup"), resolved against
+// sourceInstance and sourceCluster as before, or a fully qualified backup
+// resource name (ex.
+// "projects/other-project/instances/other-instance/clusters/other-cluster/backups/my-backup"),
+// used as-is. The latter is required to restore a backup that was copied
+// into a different project with CopyBackup, since sourceInstance and
+// sourceCluster alone can't name a cluster outside ac's own project.
+// backup may also be a "gs://" URI naming a backup archive created by
+// ExportBackup, in which case sourceInstance is ignored and the archive
+// is staged into sourceCluster via RestoreTableFromGCS.
 func (ac *AdminClient) RestoreTableFrom(ctx context.Context, sourceInstance, table, sourceCluster, backup string) error {
+	if strings.HasPrefix(backup, "gs://") {
+		return ac.RestoreTableFromGCS(ctx, table, sourceCluster, backup)
+	}
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	parent := ac.instancePrefix()
-	sourceBackupPath := ac.backupPath(sourceCluster, sourceInstance, backup)
+	sourceBackupPath := backup
+	if !strings.HasPrefix(backup, "projects/") {
+		sourceBackupPath = ac.backupPath(sourceCluster, sourceInstance, backup)
+	}
 	req := &btapb.RestoreTableRequest{
 		Parent:  parent,
 		TableId: table,
@@ -2554,6 +3715,71 @@ func (ac *AdminClient) CreateBackup(ctx context.Context, table, cluster, backup
 
 // CreateBackupWithOptions is similar to CreateBackup but lets the user specify additional options.
 func (ac *AdminClient) CreateBackupWithOptions(ctx context.Context, table, cluster, backup string, opts ...BackupOption) error {
+	op, err := ac.CreateBackupOperation(ctx, table, cluster, backup, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// BackupOperation is a handle to a CreateBackup long-running operation in
+// progress, returned by AdminClient.CreateBackupOperation.
+type BackupOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// AdminClient.BackupOperationByName to reattach to later.
+func (o *BackupOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *BackupOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *BackupOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the backup has finished, returning its
+// metadata if so. It doesn't block waiting for completion; ctx governs
+// only this one check. A nil BackupInfo and a nil error means the backup
+// is still in progress.
+func (o *BackupOperation) Poll(ctx context.Context) (*BackupInfo, error) {
+	var resp btapb.Backup
+	done, err := o.op.Poll(ctx, &resp)
+	if err != nil || !done {
+		return nil, err
+	}
+	return newBackupInfo(&resp)
+}
+
+// Wait blocks until the backup finishes, and returns its metadata.
+func (o *BackupOperation) Wait(ctx context.Context) (*BackupInfo, error) {
+	var resp btapb.Backup
+	if err := o.op.Wait(ctx, &resp); err != nil {
+		return nil, err
+	}
+	return newBackupInfo(&resp)
+}
+
+// BackupOperationByName returns a BackupOperation handle for the
+// long-running operation named name (as previously reported by another
+// BackupOperation's Name), so a process that crashed mid-backup can
+// reattach to it on restart instead of losing track of it.
+func (ac *AdminClient) BackupOperationByName(name string) *BackupOperation {
+	return &BackupOperation{op: longrunning.InternalNewOperation(ac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// CreateBackupOperation is like CreateBackupWithOptions, but returns a
+// BackupOperation handle as soon as the backup starts instead of blocking
+// until it finishes, for callers that want to poll it alongside other
+// work.
+func (ac *AdminClient) CreateBackupOperation(ctx context.Context, table, cluster, backup string, opts ...BackupOption) (*BackupOperation, error) {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	prefix := ac.instancePrefix()
 
@@ -2565,7 +3791,7 @@ func (ac *AdminClient) CreateBackupWithOptions(ctx context.Context, table, clust
 	}
 
 	if o.expireTime == nil {
-		return errExpiryMissing
+		return nil, errExpiryMissing
 	}
 	parsedExpireTime := timestamppb.New(*o.expireTime)
 
@@ -2586,10 +3812,9 @@ func (ac *AdminClient) CreateBackupWithOptions(ctx context.Context, table, clust
 	}
 	op, err := ac.tClient.CreateBackup(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp := btapb.Backup{}
-	return longrunning.InternalNewOperation(ac.lroClient, op).Wait(ctx, &resp)
+	return &BackupOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
 }
 
 // CopyBackup copies the specified source backup with the user-provided expire time.
@@ -2613,19 +3838,246 @@ func (ac *AdminClient) CopyBackup(ctx context.Context, sourceCluster, sourceBack
 	return longrunning.InternalNewOperation(ac.lroClient, op).Wait(ctx, &resp)
 }
 
-// Backups returns a BackupIterator for iterating over the backups in a cluster.
-// To list backups across all of the clusters in the instance specify "-" as the cluster.
-func (ac *AdminClient) Backups(ctx context.Context, cluster string) *BackupIterator {
-	ctx = mergeOutgoingMetadata(ctx, ac.md)
-	prefix := ac.instancePrefix()
-	clusterPath := prefix + "/clusters/" + cluster
-
-	it := &BackupIterator{}
-	req := &btapb.ListBackupsRequest{
-		Parent: clusterPath,
-	}
+// CopyBackupOperation is a handle to a CopyBackup long-running operation
+// in progress, returned by AdminClient.CopyBackupAsync.
+type CopyBackupOperation struct {
+	op *longrunning.Operation
+}
 
-	fetch := func(pageSize int, pageToken string) (string, error) {
+// CopyBackupAsync is like CopyBackup, but returns a CopyBackupOperation
+// handle as soon as the copy starts instead of blocking until it
+// finishes, for callers that want to poll it alongside other work.
+func (ac *AdminClient) CopyBackupAsync(ctx context.Context, sourceCluster, sourceBackup,
+	destProject, destInstance, destCluster, destBackup string, expireTime time.Time) (*CopyBackupOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	sourceBackupPath := ac.backupPath(sourceCluster, ac.instance, sourceBackup)
+	destPrefix := instancePrefix(destProject, destInstance)
+	req := &btapb.CopyBackupRequest{
+		Parent:       destPrefix + "/clusters/" + destCluster,
+		BackupId:     destBackup,
+		SourceBackup: sourceBackupPath,
+		ExpireTime:   timestamppb.New(expireTime),
+	}
+
+	op, err := ac.tClient.CopyBackup(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &CopyBackupOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
+}
+
+// CopyBackupOperationByName returns a CopyBackupOperation handle for the
+// long-running operation named name (as previously reported by another
+// CopyBackupOperation's Name), so a process that crashed mid-copy can
+// reattach to it on restart instead of losing track of it.
+func (ac *AdminClient) CopyBackupOperationByName(name string) *CopyBackupOperation {
+	return &CopyBackupOperation{op: longrunning.InternalNewOperation(ac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// Name returns the operation's resource name, for
+// AdminClient.CopyBackupOperationByName to reattach to later.
+func (o *CopyBackupOperation) Name() string {
+	return o.op.Name()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *CopyBackupOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Done reports whether the copy has finished, without blocking.
+func (o *CopyBackupOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Poll checks once whether the copy has finished, returning the copied
+// backup's metadata if so. It doesn't block waiting for completion; ctx
+// governs only this one check. A nil BackupInfo and a nil error means
+// the copy is still in progress.
+func (o *CopyBackupOperation) Poll(ctx context.Context) (*BackupInfo, error) {
+	var resp btapb.Backup
+	done, err := o.op.Poll(ctx, &resp)
+	if err != nil || !done {
+		return nil, err
+	}
+	return newBackupInfo(&resp)
+}
+
+// Wait blocks until the copy finishes, and returns the copied backup's
+// metadata.
+func (o *CopyBackupOperation) Wait(ctx context.Context) (*BackupInfo, error) {
+	var resp btapb.Backup
+	if err := o.op.Wait(ctx, &resp); err != nil {
+		return nil, err
+	}
+	return newBackupInfo(&resp)
+}
+
+// CopyBackupConf specifies a backup copy whose source lives in a
+// different project or instance than ac, which CopyBackup/CopyBackupAsync's
+// sourceCluster/sourceBackup shorthand can't express since it always
+// resolves the source against ac's own project and instance.
+type CopyBackupConf struct {
+	// SourceBackup is the fully-qualified resource name of the backup to
+	// copy, e.g.
+	// "projects/<source-project>/instances/<source-instance>/clusters/<source-cluster>/backups/<source-backup>".
+	SourceBackup string
+	// DestCluster is the cluster, in ac's own project and instance, to
+	// copy the backup into.
+	DestCluster string
+	// DestBackupID is the id of the new backup.
+	DestBackupID string
+	// ExpireTime is when the new backup should be automatically deleted.
+	ExpireTime time.Time
+}
+
+func (conf CopyBackupConf) validate() error {
+	if conf.SourceBackup == "" {
+		return errors.New("bigtable: CopyBackupConf.SourceBackup is required")
+	}
+	if conf.DestCluster == "" || conf.DestBackupID == "" {
+		return errors.New("bigtable: CopyBackupConf.DestCluster and DestBackupID are required")
+	}
+	if conf.ExpireTime.IsZero() {
+		return errors.New("bigtable: CopyBackupConf.ExpireTime is required")
+	}
+	return nil
+}
+
+// CopyBackupWithConf is CopyBackupAsync, but takes conf.SourceBackup as a
+// fully-qualified resource name instead of a cluster/backup pair assumed
+// to live in ac's own project and instance — the cross-project,
+// cross-instance case CopyBackup/CopyBackupAsync can't express, useful
+// for disaster-recovery workflows replicating backups from a primary
+// instance or region into a secondary one owned by ac.
+func (ac *AdminClient) CopyBackupWithConf(ctx context.Context, conf CopyBackupConf) (*CopyBackupOperation, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	req := &btapb.CopyBackupRequest{
+		Parent:       ac.instancePrefix() + "/clusters/" + conf.DestCluster,
+		BackupId:     conf.DestBackupID,
+		SourceBackup: conf.SourceBackup,
+		ExpireTime:   timestamppb.New(conf.ExpireTime),
+	}
+	op, err := ac.tClient.CopyBackup(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &CopyBackupOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
+}
+
+// Backups returns a BackupIterator for iterating over the backups in a cluster.
+// To list backups across all of the clusters in the instance specify "-" as the cluster.
+//
+// Backups is BackupsWithOptions with no options.
+func (ac *AdminClient) Backups(ctx context.Context, cluster string) *BackupIterator {
+	return ac.BackupsWithOptions(ctx, cluster)
+}
+
+// ListBackupsOption configures BackupsWithOptions's server-side filter and
+// sort order.
+type ListBackupsOption interface {
+	apply(*listBackupsConfig)
+}
+
+type listBackupsConfig struct {
+	filters []string
+	orderBy string
+}
+
+type listBackupsOptionFunc func(*listBackupsConfig)
+
+func (f listBackupsOptionFunc) apply(c *listBackupsConfig) { f(c) }
+
+// FilterBackupsBySourceTable restricts BackupsWithOptions to backups of
+// the table named tableID.
+func FilterBackupsBySourceTable(tableID string) ListBackupsOption {
+	return listBackupsOptionFunc(func(c *listBackupsConfig) {
+		c.filters = append(c.filters, fmt.Sprintf("source_table:%q", tableID))
+	})
+}
+
+// FilterBackupsByExpireTimeBefore restricts BackupsWithOptions to backups
+// that expire before t.
+func FilterBackupsByExpireTimeBefore(t time.Time) ListBackupsOption {
+	return listBackupsOptionFunc(func(c *listBackupsConfig) {
+		c.filters = append(c.filters, fmt.Sprintf("expire_time<%q", t.UTC().Format(time.RFC3339)))
+	})
+}
+
+// FilterBackupsByType restricts BackupsWithOptions to backups of the
+// given BackupType.
+func FilterBackupsByType(backupType BackupType) ListBackupsOption {
+	return listBackupsOptionFunc(func(c *listBackupsConfig) {
+		c.filters = append(c.filters, fmt.Sprintf("backup_type:%s", backupType.filterValue()))
+	})
+}
+
+// BackupOrder is a server-side sort order for BackupsWithOptions, passed
+// to OrderBackupsBy.
+type BackupOrder string
+
+// Sort orders BackupsWithOptions accepts, matching the admin API's
+// order_by grammar for ListBackupsRequest.
+const (
+	BackupOrderExpireTimeAsc  BackupOrder = "expire_time asc"
+	BackupOrderExpireTimeDesc BackupOrder = "expire_time desc"
+	BackupOrderStartTimeAsc   BackupOrder = "start_time asc"
+	BackupOrderStartTimeDesc  BackupOrder = "start_time desc"
+)
+
+// OrderBackupsBy sorts BackupsWithOptions's results server-side instead
+// of the default (undefined) order.
+func OrderBackupsBy(order BackupOrder) ListBackupsOption {
+	return listBackupsOptionFunc(func(c *listBackupsConfig) { c.orderBy = string(order) })
+}
+
+// WithBackupFilter adds a raw ListBackupsRequest filter expression to
+// BackupsWithOptions, combined via AND with any other filters, for
+// query constructs the typed FilterBackupsBy* helpers don't cover (e.g.
+// "expire_time < 2025-01-01T00:00:00Z AND backup_type=HOT" in one
+// string).
+func WithBackupFilter(filter string) ListBackupsOption {
+	return listBackupsOptionFunc(func(c *listBackupsConfig) {
+		c.filters = append(c.filters, filter)
+	})
+}
+
+// WithBackupOrderBy sets BackupsWithOptions's server-side sort order to
+// orderBy verbatim (e.g. "size_bytes desc"), for orderings
+// OrderBackupsBy's typed BackupOrder constants don't cover.
+func WithBackupOrderBy(orderBy string) ListBackupsOption {
+	return listBackupsOptionFunc(func(c *listBackupsConfig) { c.orderBy = orderBy })
+}
+
+// BackupsWithOptions is Backups, but lets the caller compose a server-side
+// filter (e.g. FilterBackupsBySourceTable) and sort order (OrderBackupsBy)
+// into the request, instead of streaming every backup in the cluster and
+// filtering in Go.
+func (ac *AdminClient) BackupsWithOptions(ctx context.Context, cluster string, opts ...ListBackupsOption) *BackupIterator {
+	cfg := listBackupsConfig{}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
+	clusterPath := prefix + "/clusters/" + cluster
+
+	it := &BackupIterator{}
+	req := &btapb.ListBackupsRequest{
+		Parent: clusterPath,
+	}
+	if len(cfg.filters) > 0 {
+		req.Filter = strings.Join(cfg.filters, " AND ")
+	}
+	if cfg.orderBy != "" {
+		req.OrderBy = cfg.orderBy
+	}
+
+	fetch := func(pageSize int, pageToken string) (string, error) {
 		req.PageToken = pageToken
 		if pageSize > math.MaxInt32 {
 			req.PageSize = math.MaxInt32
@@ -2732,6 +4184,23 @@ func (it *BackupIterator) Next() (*BackupInfo, error) {
 	return item, nil
 }
 
+// Count drains it, returning how many backups it yields and discarding
+// their metadata, for a caller that only needs cardinality (for example,
+// after BackupsWithOptions with a filter).
+func (it *BackupIterator) Count() (int, error) {
+	n := 0
+	for {
+		_, err := it.Next()
+		if err == iterator.Done {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
 // BackupType denotes the type of the backup.
 type BackupType int32
 
@@ -2751,6 +4220,20 @@ const (
 	BackupTypeHot BackupType = 2
 )
 
+// filterValue returns bt's name as the admin API's filter grammar expects
+// it for a backup_type term, matching the Backup_BackupType enum's proto
+// names.
+func (bt BackupType) filterValue() string {
+	switch bt {
+	case BackupTypeStandard:
+		return "STANDARD"
+	case BackupTypeHot:
+		return "HOT"
+	default:
+		return "TYPE_UNSPECIFIED"
+	}
+}
+
 // BackupInfo contains backup metadata. This struct is read-only.
 type BackupInfo struct {
 	Name           string
@@ -3032,6 +4515,11 @@ func (ac *AdminClient) AuthorizedViewInfo(ctx context.Context, tableID, authoriz
 		return nil, err
 	}
 
+	return authorizedViewInfoFromProto(tableID, res), nil
+}
+
+func authorizedViewInfoFromProto(tableID string, res *btapb.AuthorizedView) *AuthorizedViewInfo {
+	authorizedViewID := res.Name[strings.LastIndex(res.Name, "/")+1:]
 	av := &AuthorizedViewInfo{TableID: tableID, AuthorizedViewID: authorizedViewID}
 	if res.DeletionProtection {
 		av.DeletionProtection = Protected
@@ -3043,7 +4531,7 @@ func (ac *AdminClient) AuthorizedViewInfo(ctx context.Context, tableID, authoriz
 		s.fillInfo(res.GetSubsetView())
 		av.AuthorizedView = &s
 	}
-	return av, nil
+	return av
 }
 
 // AuthorizedViews returns a list of the authorized views in the table.
@@ -3071,17 +4559,221 @@ func (ac *AdminClient) AuthorizedViews(ctx context.Context, tableID string) ([]s
 	return names, nil
 }
 
+// AuthorizedViewsDetail selects how much detail
+// AdminClient.AuthorizedViewsWithOptions's list call returns per item.
+type AuthorizedViewsDetail int
+
+const (
+	// AuthorizedViewsNameOnly returns only each authorized view's name,
+	// matching AuthorizedViews's behavior.
+	AuthorizedViewsNameOnly AuthorizedViewsDetail = iota
+	// AuthorizedViewsFull returns each authorized view's full
+	// AuthorizedViewInfo, including SubsetView details, avoiding an N+1
+	// AuthorizedViewInfo round trip per item.
+	AuthorizedViewsFull
+)
+
+func (d AuthorizedViewsDetail) proto() btapb.AuthorizedView_ResponseView {
+	if d == AuthorizedViewsFull {
+		return btapb.AuthorizedView_FULL
+	}
+	return btapb.AuthorizedView_NAME_ONLY
+}
+
+// ListAuthorizedViewsOption configures AuthorizedViewsWithOptions.
+type ListAuthorizedViewsOption interface {
+	apply(*listAuthorizedViewsConfig)
+}
+
+type listAuthorizedViewsConfig struct {
+	detail AuthorizedViewsDetail
+}
+
+type listAuthorizedViewsOptionFunc func(*listAuthorizedViewsConfig)
+
+func (f listAuthorizedViewsOptionFunc) apply(c *listAuthorizedViewsConfig) { f(c) }
+
+// WithAuthorizedViewsDetail sets how much detail
+// AuthorizedViewsWithOptions returns per item. The default is
+// AuthorizedViewsNameOnly, matching AuthorizedViews.
+func WithAuthorizedViewsDetail(detail AuthorizedViewsDetail) ListAuthorizedViewsOption {
+	return listAuthorizedViewsOptionFunc(func(c *listAuthorizedViewsConfig) { c.detail = detail })
+}
+
+// AuthorizedViewsIterator iterates over a table's authorized views,
+// returned by AdminClient.AuthorizedViewsWithOptions. Unlike
+// AuthorizedViews's single unpaginated call, it pages through the whole
+// result set as Next is called.
+type AuthorizedViewsIterator struct {
+	items    []*AuthorizedViewInfo
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+}
+
+// PageInfo supports pagination. See https://godoc.org/google.golang.org/api/iterator package for details.
+func (it *AuthorizedViewsIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done
+// (https://godoc.org/google.golang.org/api/iterator) if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *AuthorizedViewsIterator) Next() (*AuthorizedViewInfo, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// Count drains it, returning how many authorized views it yields and
+// discarding their metadata, for a caller that only needs cardinality.
+func (it *AuthorizedViewsIterator) Count() (int, error) {
+	n := 0
+	for {
+		_, err := it.Next()
+		if err == iterator.Done {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// AuthorizedViewsWithOptions is AuthorizedViews, but returns a paginated
+// AuthorizedViewsIterator instead of the []string of a single
+// unpaginated list call, and — via WithAuthorizedViewsDetail(AuthorizedViewsFull) —
+// lets the caller request full AuthorizedViewInfo detail in the list
+// call itself, avoiding an N+1 AuthorizedViewInfo round trip per item.
+func (ac *AdminClient) AuthorizedViewsWithOptions(ctx context.Context, tableID string, opts ...ListAuthorizedViewsOption) *AuthorizedViewsIterator {
+	cfg := listAuthorizedViewsConfig{detail: AuthorizedViewsNameOnly}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := fmt.Sprintf("%s/tables/%s", ac.instancePrefix(), tableID)
+	req := &btapb.ListAuthorizedViewsRequest{
+		Parent: prefix,
+		View:   cfg.detail.proto(),
+	}
+
+	it := &AuthorizedViewsIterator{}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		req.PageToken = pageToken
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else {
+			req.PageSize = int32(pageSize)
+		}
+
+		var res *btapb.ListAuthorizedViewsResponse
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+			var err error
+			res, err = ac.tClient.ListAuthorizedViews(ctx, req)
+			return err
+		}, adminRetryOptions...)
+		if err != nil {
+			return "", err
+		}
+		for _, av := range res.AuthorizedViews {
+			it.items = append(it.items, authorizedViewInfoFromProto(tableID, av))
+		}
+		return res.NextPageToken, nil
+	}
+
+	bufLen := func() int { return len(it.items) }
+	takeBuf := func() interface{} { b := it.items; it.items = nil; return b }
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, bufLen, takeBuf)
+	return it
+}
+
 // UpdateAuthorizedViewConf contains all the information necessary to update or partial update an authorized view.
 type UpdateAuthorizedViewConf struct {
 	AuthorizedViewConf AuthorizedViewConf
 	IgnoreWarnings     bool
 }
 
-// UpdateAuthorizedView updates an authorized view in a table according to the given configuration.
+// UpdateAuthorizedView updates an authorized view in a table according to
+// the given configuration, blocking until the update finishes.
+//
+// UpdateAuthorizedView is UpdateAuthorizedViewAsync followed by Wait.
 func (ac *AdminClient) UpdateAuthorizedView(ctx context.Context, conf UpdateAuthorizedViewConf) error {
+	op, err := ac.UpdateAuthorizedViewAsync(ctx, conf)
+	if err != nil {
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// AuthorizedViewOperation is a handle to an UpdateAuthorizedView
+// long-running operation in progress, returned by
+// AdminClient.UpdateAuthorizedViewAsync or
+// AdminClient.AuthorizedViewOperationByName.
+type AuthorizedViewOperation struct {
+	op    *longrunning.Operation
+	table string
+}
+
+// Name returns the operation's resource name, for
+// AdminClient.AuthorizedViewOperationByName to reattach to later.
+func (o *AuthorizedViewOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *AuthorizedViewOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *AuthorizedViewOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the update has finished, without blocking;
+// ctx governs only this one check.
+func (o *AuthorizedViewOperation) Poll(ctx context.Context) (*AuthorizedViewInfo, error) {
+	var res btapb.AuthorizedView
+	done, err := o.op.Poll(ctx, &res)
+	if err != nil || !done {
+		return nil, err
+	}
+	return authorizedViewInfoFromProto(o.table, &res), nil
+}
+
+// Wait blocks until the update finishes and returns the resulting
+// authorized view's metadata.
+func (o *AuthorizedViewOperation) Wait(ctx context.Context) (*AuthorizedViewInfo, error) {
+	var res btapb.AuthorizedView
+	if err := o.op.Wait(ctx, &res); err != nil {
+		return nil, err
+	}
+	return authorizedViewInfoFromProto(o.table, &res), nil
+}
+
+// AuthorizedViewOperationByName returns an AuthorizedViewOperation handle
+// for the long-running operation named name (as previously reported by
+// another AuthorizedViewOperation's Name), so a process that crashed
+// mid-update can reattach to it on restart instead of losing track of it.
+func (ac *AdminClient) AuthorizedViewOperationByName(table, name string) *AuthorizedViewOperation {
+	return &AuthorizedViewOperation{
+		op:    longrunning.InternalNewOperation(ac.lroClient, &longrunningpb.Operation{Name: name}),
+		table: table,
+	}
+}
+
+// UpdateAuthorizedViewAsync is UpdateAuthorizedView, but returns an
+// AuthorizedViewOperation handle instead of blocking until the update
+// finishes.
+func (ac *AdminClient) UpdateAuthorizedViewAsync(ctx context.Context, conf UpdateAuthorizedViewConf) (*AuthorizedViewOperation, error) {
 	ctx = mergeOutgoingMetadata(ctx, ac.md)
 	if conf.AuthorizedViewConf.TableID == "" || conf.AuthorizedViewConf.AuthorizedViewID == "" {
-		return errors.New("both AuthorizedViewID and TableID is required")
+		return nil, errors.New("both AuthorizedViewID and TableID is required")
 	}
 	av := conf.AuthorizedViewConf.proto()
 	av.Name = ac.authorizedViewPath(conf.AuthorizedViewConf.TableID, conf.AuthorizedViewConf.AuthorizedViewID)
@@ -3095,6 +4787,9 @@ func (ac *AdminClient) UpdateAuthorizedView(ctx context.Context, conf UpdateAuth
 	if _, ok := conf.AuthorizedViewConf.AuthorizedView.(*SubsetViewConf); ok {
 		updateMask.Paths = append(updateMask.Paths, "subset_view")
 	}
+	if len(updateMask.Paths) == 0 {
+		return nil, errors.New("UpdateAuthorizedViewConf must set DeletionProtection, AuthorizedView, or both")
+	}
 	req := &btapb.UpdateAuthorizedViewRequest{
 		AuthorizedView: av,
 		UpdateMask:     updateMask,
@@ -3102,14 +4797,12 @@ func (ac *AdminClient) UpdateAuthorizedView(ctx context.Context, conf UpdateAuth
 	}
 	lro, err := ac.tClient.UpdateAuthorizedView(ctx, req)
 	if err != nil {
-		return fmt.Errorf("error from update authorized view: %w", err)
+		return nil, fmt.Errorf("error from update authorized view: %w", err)
 	}
-	var res btapb.AuthorizedView
-	op := longrunning.InternalNewOperation(ac.lroClient, lro)
-	if err = op.Wait(ctx, &res); err != nil {
-		return fmt.Errorf("error from operation: %v", err)
-	}
-	return nil
+	return &AuthorizedViewOperation{
+		op:    longrunning.InternalNewOperation(ac.lroClient, lro),
+		table: conf.AuthorizedViewConf.TableID,
+	}, nil
 }
 
 // DeleteAuthorizedView deletes an authorized view in a table.
@@ -3124,10 +4817,80 @@ func (ac *AdminClient) DeleteAuthorizedView(ctx context.Context, tableID, author
 
 // Logical Views
 
-// CreateLogicalView creates a new logical view in an instance.
+// CreateLogicalView creates a new logical view in an instance, blocking
+// until creation finishes.
+//
+// CreateLogicalView is CreateLogicalViewAsync followed by Wait.
 func (iac *InstanceAdminClient) CreateLogicalView(ctx context.Context, instanceID string, conf *LogicalViewInfo) error {
+	op, err := iac.CreateLogicalViewAsync(ctx, instanceID, conf)
+	if err != nil {
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// LogicalViewOperation is a handle to a CreateLogicalView or
+// UpdateLogicalView long-running operation in progress, returned by
+// InstanceAdminClient.CreateLogicalViewAsync,
+// InstanceAdminClient.UpdateLogicalViewAsync, or
+// InstanceAdminClient.LogicalViewOperationByName.
+type LogicalViewOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// InstanceAdminClient.LogicalViewOperationByName to reattach to later.
+func (o *LogicalViewOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *LogicalViewOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *LogicalViewOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *LogicalViewOperation) Poll(ctx context.Context) (*LogicalViewInfo, error) {
+	var res btapb.LogicalView
+	done, err := o.op.Poll(ctx, &res)
+	if err != nil || !done {
+		return nil, err
+	}
+	return logicalViewInfoFromProto(&res), nil
+}
+
+// Wait blocks until the operation finishes and returns the resulting
+// logical view's metadata.
+func (o *LogicalViewOperation) Wait(ctx context.Context) (*LogicalViewInfo, error) {
+	var res btapb.LogicalView
+	if err := o.op.Wait(ctx, &res); err != nil {
+		return nil, err
+	}
+	return logicalViewInfoFromProto(&res), nil
+}
+
+// LogicalViewOperationByName returns a LogicalViewOperation handle for
+// the long-running operation named name (as previously reported by
+// another LogicalViewOperation's Name), so a process that crashed
+// mid-create/update can reattach to it on restart instead of losing
+// track of it.
+func (iac *InstanceAdminClient) LogicalViewOperationByName(name string) *LogicalViewOperation {
+	return &LogicalViewOperation{op: longrunning.InternalNewOperation(iac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// CreateLogicalViewAsync is CreateLogicalView, but returns a
+// LogicalViewOperation handle instead of blocking until creation
+// finishes.
+func (iac *InstanceAdminClient) CreateLogicalViewAsync(ctx context.Context, instanceID string, conf *LogicalViewInfo) (*LogicalViewOperation, error) {
 	if conf.LogicalViewID == "" {
-		return errors.New("LogicalViewID is required")
+		return nil, errors.New("LogicalViewID is required")
 	}
 
 	lv := &btapb.LogicalView{
@@ -3153,10 +4916,9 @@ func (iac *InstanceAdminClient) CreateLogicalView(ctx context.Context, instanceI
 
 	op, err := iac.iClient.CreateLogicalView(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp := btapb.LogicalView{}
-	return longrunning.InternalNewOperation(iac.lroClient, op).Wait(ctx, &resp)
+	return &LogicalViewOperation{op: longrunning.InternalNewOperation(iac.lroClient, op)}, nil
 }
 
 // LogicalViewInfo contains logical view metadata. This struct is read-only.
@@ -3185,13 +4947,17 @@ func (iac *InstanceAdminClient) LogicalViewInfo(ctx context.Context, instanceID,
 	if err != nil {
 		return nil, err
 	}
-	lv := &LogicalViewInfo{LogicalViewID: strings.TrimPrefix(res.Name, prefix+"/logicalViews/"), Query: res.Query}
+	return logicalViewInfoFromProto(res), nil
+}
+
+func logicalViewInfoFromProto(res *btapb.LogicalView) *LogicalViewInfo {
+	lv := &LogicalViewInfo{LogicalViewID: res.Name[strings.LastIndex(res.Name, "/")+1:], Query: res.Query}
 	if res.DeletionProtection {
 		lv.DeletionProtection = Protected
 	} else {
 		lv.DeletionProtection = Unprotected
 	}
-	return lv, nil
+	return lv
 }
 
 // LogicalViews returns a list of the logical views in the instance.
@@ -3212,22 +4978,31 @@ func (iac *InstanceAdminClient) LogicalViews(ctx context.Context, instanceID str
 	}
 
 	for _, lView := range res.LogicalViews {
-		lv := LogicalViewInfo{LogicalViewID: strings.TrimPrefix(lView.Name, prefix+"/logicalViews/"), Query: lView.Query}
-		if lView.DeletionProtection {
-			lv.DeletionProtection = Protected
-		} else {
-			lv.DeletionProtection = Unprotected
-		}
-		views = append(views, lv)
+		views = append(views, *logicalViewInfoFromProto(lView))
 	}
 	return views, nil
 }
 
-// UpdateLogicalView updates a logical view in an instance according to the given configuration.
+// UpdateLogicalView updates a logical view in an instance according to
+// the given configuration, blocking until the update finishes.
+//
+// UpdateLogicalView is UpdateLogicalViewAsync followed by Wait.
 func (iac *InstanceAdminClient) UpdateLogicalView(ctx context.Context, instanceID string, conf LogicalViewInfo) error {
+	op, err := iac.UpdateLogicalViewAsync(ctx, instanceID, conf)
+	if err != nil {
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// UpdateLogicalViewAsync is UpdateLogicalView, but returns a
+// LogicalViewOperation handle instead of blocking until the update
+// finishes.
+func (iac *InstanceAdminClient) UpdateLogicalViewAsync(ctx context.Context, instanceID string, conf LogicalViewInfo) (*LogicalViewOperation, error) {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 	if conf.LogicalViewID == "" {
-		return errors.New("LogicalViewID is required")
+		return nil, errors.New("LogicalViewID is required")
 	}
 	lv := &btapb.LogicalView{}
 	lv.Name = logicalViewPath(iac.project, instanceID, conf.LogicalViewID)
@@ -3256,14 +5031,9 @@ func (iac *InstanceAdminClient) UpdateLogicalView(ctx context.Context, instanceI
 	}
 	lro, err := iac.iClient.UpdateLogicalView(ctx, req)
 	if err != nil {
-		return fmt.Errorf("error from update logical view: %w", err)
-	}
-	var res btapb.LogicalView
-	op := longrunning.InternalNewOperation(iac.lroClient, lro)
-	if err = op.Wait(ctx, &res); err != nil {
-		return fmt.Errorf("error from operation: %v", err)
+		return nil, fmt.Errorf("error from update logical view: %w", err)
 	}
-	return nil
+	return &LogicalViewOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
 }
 
 // DeleteLogicalView deletes a logical view in an instance.
@@ -3278,10 +5048,80 @@ func (iac *InstanceAdminClient) DeleteLogicalView(ctx context.Context, instanceI
 
 // Materialized Views
 
-// CreateMaterializedView creates a new materialized view in an instance.
+// CreateMaterializedView creates a new materialized view in an instance,
+// blocking until creation finishes.
+//
+// CreateMaterializedView is CreateMaterializedViewAsync followed by Wait.
 func (iac *InstanceAdminClient) CreateMaterializedView(ctx context.Context, instanceID string, conf *MaterializedViewInfo) error {
+	op, err := iac.CreateMaterializedViewAsync(ctx, instanceID, conf)
+	if err != nil {
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// MaterializedViewOperation is a handle to a CreateMaterializedView or
+// UpdateMaterializedView long-running operation in progress, returned by
+// InstanceAdminClient.CreateMaterializedViewAsync,
+// InstanceAdminClient.UpdateMaterializedViewAsync, or
+// InstanceAdminClient.MaterializedViewOperationByName.
+type MaterializedViewOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// InstanceAdminClient.MaterializedViewOperationByName to reattach to later.
+func (o *MaterializedViewOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *MaterializedViewOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *MaterializedViewOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *MaterializedViewOperation) Poll(ctx context.Context) (*MaterializedViewInfo, error) {
+	var res btapb.MaterializedView
+	done, err := o.op.Poll(ctx, &res)
+	if err != nil || !done {
+		return nil, err
+	}
+	return materializedViewInfoFromProto(&res), nil
+}
+
+// Wait blocks until the operation finishes and returns the resulting
+// materialized view's metadata.
+func (o *MaterializedViewOperation) Wait(ctx context.Context) (*MaterializedViewInfo, error) {
+	var res btapb.MaterializedView
+	if err := o.op.Wait(ctx, &res); err != nil {
+		return nil, err
+	}
+	return materializedViewInfoFromProto(&res), nil
+}
+
+// MaterializedViewOperationByName returns a MaterializedViewOperation
+// handle for the long-running operation named name (as previously
+// reported by another MaterializedViewOperation's Name), so a process
+// that crashed mid-create/update can reattach to it on restart instead
+// of losing track of it.
+func (iac *InstanceAdminClient) MaterializedViewOperationByName(name string) *MaterializedViewOperation {
+	return &MaterializedViewOperation{op: longrunning.InternalNewOperation(iac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// CreateMaterializedViewAsync is CreateMaterializedView, but returns a
+// MaterializedViewOperation handle instead of blocking until creation
+// finishes.
+func (iac *InstanceAdminClient) CreateMaterializedViewAsync(ctx context.Context, instanceID string, conf *MaterializedViewInfo) (*MaterializedViewOperation, error) {
 	if conf.MaterializedViewID == "" {
-		return errors.New("MaterializedViewID is required")
+		return nil, errors.New("MaterializedViewID is required")
 	}
 
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
@@ -3298,6 +5138,9 @@ func (iac *InstanceAdminClient) CreateMaterializedView(ctx context.Context, inst
 			break
 		}
 	}
+	if conf.RefreshPolicy != nil {
+		mv.RefreshPolicy = conf.RefreshPolicy.proto()
+	}
 	req := &btapb.CreateMaterializedViewRequest{
 		Parent:             instancePrefix(iac.project, instanceID),
 		MaterializedViewId: conf.MaterializedViewID,
@@ -3305,10 +5148,9 @@ func (iac *InstanceAdminClient) CreateMaterializedView(ctx context.Context, inst
 	}
 	op, err := iac.iClient.CreateMaterializedView(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp := btapb.MaterializedView{}
-	return longrunning.InternalNewOperation(iac.lroClient, op).Wait(ctx, &resp)
+	return &MaterializedViewOperation{op: longrunning.InternalNewOperation(iac.lroClient, op)}, nil
 }
 
 // MaterializedViewInfo contains materialized view metadata. This struct is read-only.
@@ -3317,12 +5159,70 @@ type MaterializedViewInfo struct {
 
 	Query              string
 	DeletionProtection DeletionProtection
+	// RefreshPolicy controls how the view's result is kept up to date with
+	// its underlying table. A nil RefreshPolicy leaves the view's current
+	// policy (or the server's default) unchanged.
+	RefreshPolicy *RefreshPolicy
+}
+
+// RefreshMode selects how a MaterializedView's result is recomputed.
+type RefreshMode int
+
+const (
+	// RefreshContinuous keeps the view's result incrementally up to date
+	// as its underlying table changes.
+	RefreshContinuous RefreshMode = iota
+	// RefreshScheduled recomputes the view's result on a fixed Interval
+	// instead of continuously.
+	RefreshScheduled
+)
+
+// RefreshPolicy is a MaterializedView's refresh policy, set via
+// MaterializedViewInfo.RefreshPolicy.
+type RefreshPolicy struct {
+	Mode RefreshMode
+	// Interval is how often the view is recomputed. Only meaningful when
+	// Mode is RefreshScheduled.
+	Interval time.Duration
+}
+
+func (p *RefreshPolicy) proto() *btapb.MaterializedView_RefreshPolicy {
+	if p == nil {
+		return nil
+	}
+	if p.Mode == RefreshScheduled {
+		return &btapb.MaterializedView_RefreshPolicy{
+			Mode: &btapb.MaterializedView_RefreshPolicy_Scheduled_{
+				Scheduled: &btapb.MaterializedView_RefreshPolicy_Scheduled{
+					Interval: durationpb.New(p.Interval),
+				},
+			},
+		}
+	}
+	return &btapb.MaterializedView_RefreshPolicy{
+		Mode: &btapb.MaterializedView_RefreshPolicy_Continuous_{
+			Continuous: &btapb.MaterializedView_RefreshPolicy_Continuous{},
+		},
+	}
+}
+
+func refreshPolicyFromProto(p *btapb.MaterializedView_RefreshPolicy) *RefreshPolicy {
+	if p == nil {
+		return nil
+	}
+	switch m := p.GetMode().(type) {
+	case *btapb.MaterializedView_RefreshPolicy_Scheduled_:
+		return &RefreshPolicy{Mode: RefreshScheduled, Interval: m.Scheduled.GetInterval().AsDuration()}
+	case *btapb.MaterializedView_RefreshPolicy_Continuous_:
+		return &RefreshPolicy{Mode: RefreshContinuous}
+	default:
+		return nil
+	}
 }
 
 // MaterializedViewInfo retrieves information about a materialized view.
 func (iac *InstanceAdminClient) MaterializedViewInfo(ctx context.Context, instanceID, materializedViewID string) (*MaterializedViewInfo, error) {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
-	prefix := instancePrefix(iac.project, instanceID)
 	req := &btapb.GetMaterializedViewRequest{
 		Name: materializedlViewPath(iac.project, instanceID, materializedViewID),
 	}
@@ -3337,49 +5237,123 @@ func (iac *InstanceAdminClient) MaterializedViewInfo(ctx context.Context, instan
 	if err != nil {
 		return nil, err
 	}
-	mv := &MaterializedViewInfo{MaterializedViewID: strings.TrimPrefix(res.Name, prefix+"/materializedViews/"), Query: res.Query}
+	return materializedViewInfoFromProto(res), nil
+}
+
+func materializedViewInfoFromProto(res *btapb.MaterializedView) *MaterializedViewInfo {
+	mv := &MaterializedViewInfo{MaterializedViewID: res.Name[strings.LastIndex(res.Name, "/")+1:], Query: res.Query}
 	if res.DeletionProtection {
 		mv.DeletionProtection = Protected
 	} else {
 		mv.DeletionProtection = Unprotected
 	}
-	return mv, nil
+	mv.RefreshPolicy = refreshPolicyFromProto(res.GetRefreshPolicy())
+	return mv
 }
 
-// MaterializedViews returns a list of the materialized views in the instance.
+// MaterializedViews returns a list of the materialized views in the
+// instance. It drains a MaterializedViewIterator internally, so it pages
+// through the whole result set rather than returning just the first page.
 func (iac *InstanceAdminClient) MaterializedViews(ctx context.Context, instanceID string) ([]MaterializedViewInfo, error) {
 	views := []MaterializedViewInfo{}
+	it := iac.MaterializedViewIterator(ctx, instanceID)
+	for {
+		mv, err := it.Next()
+		if err == iterator.Done {
+			return views, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *mv)
+	}
+}
+
+// MaterializedViewIterator iterates over an instance's materialized
+// views, returned by InstanceAdminClient.MaterializedViewIterator.
+// Unlike MaterializedViews, which drains every page before returning, it
+// pages through the result set as Next is called.
+type MaterializedViewIterator struct {
+	items    []*MaterializedViewInfo
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+}
+
+// PageInfo supports pagination. See https://godoc.org/google.golang.org/api/iterator package for details.
+func (it *MaterializedViewIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done
+// (https://godoc.org/google.golang.org/api/iterator) if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *MaterializedViewIterator) Next() (*MaterializedViewInfo, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// MaterializedViewIterator returns a paginated iterator over instanceID's
+// materialized views, following the ListMaterializedViewsRequest's
+// NextPageToken across as many pages as it takes. MaterializedViews
+// drains this iterator into a slice.
+func (iac *InstanceAdminClient) MaterializedViewIterator(ctx context.Context, instanceID string) *MaterializedViewIterator {
+	ctx = mergeOutgoingMetadata(ctx, iac.md)
 	prefix := instancePrefix(iac.project, instanceID)
 	req := &btapb.ListMaterializedViewsRequest{
 		Parent: prefix,
 	}
-	var res *btapb.ListMaterializedViewsResponse
-	err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
-		var err error
-		res, err = iac.iClient.ListMaterializedViews(ctx, req)
-		return err
-	}, adminRetryOptions...)
-	if err != nil {
-		return nil, err
-	}
 
-	for _, mView := range res.MaterializedViews {
-		mv := MaterializedViewInfo{MaterializedViewID: strings.TrimPrefix(mView.Name, prefix+"/materializedViews/"), Query: mView.Query}
-		if mView.DeletionProtection {
-			mv.DeletionProtection = Protected
+	it := &MaterializedViewIterator{}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		req.PageToken = pageToken
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
 		} else {
-			mv.DeletionProtection = Unprotected
+			req.PageSize = int32(pageSize)
+		}
+
+		var res *btapb.ListMaterializedViewsResponse
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+			var err error
+			res, err = iac.iClient.ListMaterializedViews(ctx, req)
+			return err
+		}, adminRetryOptions...)
+		if err != nil {
+			return "", err
+		}
+		for _, mView := range res.MaterializedViews {
+			it.items = append(it.items, materializedViewInfoFromProto(mView))
 		}
-		views = append(views, mv)
+		return res.NextPageToken, nil
 	}
-	return views, nil
+
+	bufLen := func() int { return len(it.items) }
+	takeBuf := func() interface{} { b := it.items; it.items = nil; return b }
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, bufLen, takeBuf)
+	return it
 }
 
 // UpdateMaterializedView updates a materialized view in an instance according to the given configuration.
 func (iac *InstanceAdminClient) UpdateMaterializedView(ctx context.Context, instanceID string, conf MaterializedViewInfo) error {
+	op, err := iac.UpdateMaterializedViewAsync(ctx, instanceID, conf)
+	if err != nil {
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// UpdateMaterializedViewAsync is UpdateMaterializedView, but returns a
+// MaterializedViewOperation handle instead of blocking until the update
+// finishes.
+func (iac *InstanceAdminClient) UpdateMaterializedViewAsync(ctx context.Context, instanceID string, conf MaterializedViewInfo) (*MaterializedViewOperation, error) {
 	ctx = mergeOutgoingMetadata(ctx, iac.md)
 	if conf.MaterializedViewID == "" {
-		return errors.New("MaterializedViewID is required")
+		return nil, errors.New("MaterializedViewID is required")
 	}
 	mv := &btapb.MaterializedView{}
 	mv.Name = materializedlViewPath(iac.project, instanceID, conf.MaterializedViewID)
@@ -3402,20 +5376,19 @@ func (iac *InstanceAdminClient) UpdateMaterializedView(ctx context.Context, inst
 			break
 		}
 	}
+	if conf.RefreshPolicy != nil {
+		updateMask.Paths = append(updateMask.Paths, "refresh_policy")
+		mv.RefreshPolicy = conf.RefreshPolicy.proto()
+	}
 	req := &btapb.UpdateMaterializedViewRequest{
 		MaterializedView: mv,
 		UpdateMask:       updateMask,
 	}
 	lro, err := iac.iClient.UpdateMaterializedView(ctx, req)
 	if err != nil {
-		return fmt.Errorf("error from update materialized view: %w", err)
-	}
-	var res btapb.MaterializedView
-	op := longrunning.InternalNewOperation(iac.lroClient, lro)
-	if err = op.Wait(ctx, &res); err != nil {
-		return fmt.Errorf("error from operation: %v", err)
+		return nil, fmt.Errorf("error from update materialized view: %w", err)
 	}
-	return nil
+	return &MaterializedViewOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
 }
 
 // DeleteMaterializedView deletes a materialized view in an instance.
@@ -3516,28 +5489,103 @@ func (ac *AdminClient) GetSchemaBundle(ctx context.Context, tableID, schemaBundl
 	return sb, nil
 }
 
-// SchemaBundles returns a list of the schema bundles in the table.
+// SchemaBundles returns a list of the schema bundles in the table. It
+// drains a SchemaBundleIterator internally, so it pages through the
+// whole result set rather than returning just the first page.
 func (ac *AdminClient) SchemaBundles(ctx context.Context, tableID string) ([]string, error) {
 	names := []string{}
-	prefix := fmt.Sprintf("%s/tables/%s", ac.instancePrefix(), tableID)
+	it := ac.SchemaBundleIterator(ctx, tableID)
+	for {
+		sb, err := it.Next()
+		if err == iterator.Done {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, sb.SchemaBundleID)
+	}
+}
 
-	req := &btapb.ListSchemaBundlesRequest{
-		Parent: prefix,
+func schemaBundleInfoFromProto(tableID string, res *btapb.SchemaBundle) *SchemaBundleInfo {
+	sb := &SchemaBundleInfo{
+		TableID:        tableID,
+		SchemaBundleID: res.Name[strings.LastIndex(res.Name, "/")+1:],
+		Etag:           res.Etag,
 	}
-	var res *btapb.ListSchemaBundlesResponse
-	err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
-		var err error
-		res, err = ac.tClient.ListSchemaBundles(ctx, req)
-		return err
-	}, adminRetryOptions...)
-	if err != nil {
+	if len(res.GetProtoSchema().GetProtoDescriptors()) > 0 {
+		sb.SchemaBundle = res.GetProtoSchema().GetProtoDescriptors()
+	}
+	return sb
+}
+
+// SchemaBundleIterator iterates over a table's schema bundles, returned
+// by AdminClient.SchemaBundleIterator. Unlike SchemaBundles's []string,
+// it yields the full SchemaBundleInfo per bundle (including Etag and
+// descriptors), so a caller diffing bundles doesn't need a separate
+// GetSchemaBundle round trip per item.
+type SchemaBundleIterator struct {
+	items    []*SchemaBundleInfo
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+}
+
+// PageInfo supports pagination. See https://godoc.org/google.golang.org/api/iterator package for details.
+func (it *SchemaBundleIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done
+// (https://godoc.org/google.golang.org/api/iterator) if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *SchemaBundleIterator) Next() (*SchemaBundleInfo, error) {
+	if err := it.nextFunc(); err != nil {
 		return nil, err
 	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// SchemaBundleIterator returns a paginated iterator over tableID's
+// schema bundles, following the ListSchemaBundlesRequest's NextPageToken
+// across as many pages as it takes. SchemaBundles drains this iterator
+// into a slice of IDs.
+func (ac *AdminClient) SchemaBundleIterator(ctx context.Context, tableID string) *SchemaBundleIterator {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := fmt.Sprintf("%s/tables/%s", ac.instancePrefix(), tableID)
+	req := &btapb.ListSchemaBundlesRequest{
+		Parent: prefix,
+	}
+
+	it := &SchemaBundleIterator{}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		req.PageToken = pageToken
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else {
+			req.PageSize = int32(pageSize)
+		}
 
-	for _, res := range res.SchemaBundles {
-		names = append(names, strings.TrimPrefix(res.Name, prefix+"/schemaBundles/"))
+		var res *btapb.ListSchemaBundlesResponse
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+			var err error
+			res, err = ac.tClient.ListSchemaBundles(ctx, req)
+			return err
+		}, adminRetryOptions...)
+		if err != nil {
+			return "", err
+		}
+		for _, sb := range res.SchemaBundles {
+			it.items = append(it.items, schemaBundleInfoFromProto(tableID, sb))
+		}
+		return res.NextPageToken, nil
 	}
-	return names, nil
+
+	bufLen := func() int { return len(it.items) }
+	takeBuf := func() interface{} { b := it.items; it.items = nil; return b }
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, bufLen, takeBuf)
+	return it
 }
 
 // UpdateSchemaBundleConf contains all the information necessary to update or partial update a schema bundle.
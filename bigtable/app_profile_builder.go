@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import "errors"
+
+// AppProfileBuilder builds a ProfileConf fluently, catching illegal
+// combinations of routing policy and isolation config (e.g. transactional
+// writes paired with Data Boost, or row affinity on single-cluster
+// routing) before a CreateAppProfile or UpdateAppProfile call ever
+// reaches the server.
+type AppProfileBuilder struct {
+	instanceID, profileID string
+
+	description string
+	etag        string
+
+	routing     RoutingPolicyConfig
+	rowAffinity bool
+	isolation   AppProfileIsolation
+
+	ignoreWarnings bool
+}
+
+// NewAppProfileBuilder returns a builder for an app profile with the given
+// profileID in instanceID.
+func NewAppProfileBuilder(instanceID, profileID string) *AppProfileBuilder {
+	return &AppProfileBuilder{instanceID: instanceID, profileID: profileID}
+}
+
+// WithDescription sets the app profile's description.
+func (b *AppProfileBuilder) WithDescription(description string) *AppProfileBuilder {
+	b.description = description
+	return b
+}
+
+// WithEtag sets the etag to use for the app profile, guarding against
+// concurrent modification.
+func (b *AppProfileBuilder) WithEtag(etag string) *AppProfileBuilder {
+	b.etag = etag
+	return b
+}
+
+// WithIgnoreWarnings sets whether server-side warnings about the app
+// profile's configuration should be ignored rather than rejected.
+func (b *AppProfileBuilder) WithIgnoreWarnings(ignoreWarnings bool) *AppProfileBuilder {
+	b.ignoreWarnings = ignoreWarnings
+	return b
+}
+
+// WithSingleCluster routes all read/write requests to clusterID. Set
+// allowTransactionalWrites to allow CheckAndMutateRow and
+// ReadModifyWriteRow requests through this app profile.
+func (b *AppProfileBuilder) WithSingleCluster(clusterID string, allowTransactionalWrites bool) *AppProfileBuilder {
+	b.routing = &SingleClusterRoutingConfig{ClusterID: clusterID, AllowTransactionalWrites: allowTransactionalWrites}
+	return b
+}
+
+// WithMultiCluster routes requests to the nearest available cluster among
+// clusterIDs, or any cluster in the instance if clusterIDs is empty.
+func (b *AppProfileBuilder) WithMultiCluster(clusterIDs ...string) *AppProfileBuilder {
+	b.routing = &MultiClusterRoutingUseAnyConfig{ClusterIDs: clusterIDs}
+	return b
+}
+
+// WithRowAffinity enables row-based affinity for a multi-cluster routing
+// policy: requests for a given row key are routed to the same cluster
+// rather than randomly among equidistant clusters. It only applies to a
+// routing policy set by WithMultiCluster; call it after WithMultiCluster.
+func (b *AppProfileBuilder) WithRowAffinity() *AppProfileBuilder {
+	b.rowAffinity = true
+	return b
+}
+
+// WithStandardIsolation configures standard traffic isolation at the
+// given priority.
+func (b *AppProfileBuilder) WithStandardIsolation(priority AppProfilePriority) *AppProfileBuilder {
+	b.isolation = &StandardIsolation{Priority: priority}
+	return b
+}
+
+// WithDataBoost configures Data Boost isolation, billed to owner. Data
+// Boost only supports read-only, single-cluster routing, so it cannot be
+// combined with AllowTransactionalWrites.
+func (b *AppProfileBuilder) WithDataBoost(owner IsolationComputeBillingOwner) *AppProfileBuilder {
+	b.isolation = &DataBoostIsolationReadOnly{ComputeBillingOwner: owner}
+	return b
+}
+
+// Validate reports an error if the builder's configuration is one Cloud
+// Bigtable will reject: no routing policy set, row affinity requested
+// without multi-cluster routing, or Data Boost isolation combined with
+// transactional writes.
+func (b *AppProfileBuilder) Validate() error {
+	if b.routing == nil {
+		return errors.New("bigtable: AppProfileBuilder requires WithSingleCluster or WithMultiCluster")
+	}
+	single, isSingle := b.routing.(*SingleClusterRoutingConfig)
+	_, isMulti := b.routing.(*MultiClusterRoutingUseAnyConfig)
+	if b.rowAffinity && !isMulti {
+		return errors.New("bigtable: WithRowAffinity requires WithMultiCluster routing")
+	}
+	if _, isDataBoost := b.isolation.(*DataBoostIsolationReadOnly); isDataBoost && isSingle && single.AllowTransactionalWrites {
+		return errors.New("bigtable: DataBoostIsolationReadOnly cannot be combined with AllowTransactionalWrites")
+	}
+	return nil
+}
+
+// Build validates the builder's configuration and returns the resulting
+// ProfileConf, ready to pass to CreateAppProfile or embed in an
+// UpdateAppProfile call. Existing call sites that construct a ProfileConf
+// literal directly are unaffected; Build is purely an additional way to
+// arrive at one.
+func (b *AppProfileBuilder) Build() (ProfileConf, error) {
+	if err := b.Validate(); err != nil {
+		return ProfileConf{}, err
+	}
+	if multi, ok := b.routing.(*MultiClusterRoutingUseAnyConfig); ok && b.rowAffinity {
+		multi.Affinity = &RowAffinity{}
+	}
+	return ProfileConf{
+		InstanceID:     b.instanceID,
+		ProfileID:      b.profileID,
+		Description:    b.description,
+		Etag:           b.etag,
+		RoutingConfig:  b.routing,
+		Isolation:      b.isolation,
+		IgnoreWarnings: b.ignoreWarnings,
+	}, nil
+}
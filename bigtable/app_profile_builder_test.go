@@ -0,0 +1,169 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import "testing"
+
+func TestAppProfileBuilder_Rejections(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		builder *AppProfileBuilder
+	}{
+		{
+			desc:    "no routing policy",
+			builder: NewAppProfileBuilder("my-instance", "my-profile"),
+		},
+		{
+			desc: "row affinity on single-cluster routing",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").
+				WithSingleCluster("cluster-1", false).
+				WithRowAffinity(),
+		},
+		{
+			desc: "data boost with transactional writes",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").
+				WithSingleCluster("cluster-1", true).
+				WithDataBoost(HostPays),
+		},
+	} {
+		if err := test.builder.Validate(); err == nil {
+			t.Errorf("%s: Validate got nil, want error", test.desc)
+		}
+		if _, err := test.builder.Build(); err == nil {
+			t.Errorf("%s: Build got nil error, want error", test.desc)
+		}
+	}
+}
+
+func TestAppProfileBuilder_Success(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		builder *AppProfileBuilder
+		want    ProfileConf
+	}{
+		{
+			desc:    "single cluster",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").WithSingleCluster("cluster-1", true),
+			want: ProfileConf{
+				InstanceID:    "my-instance",
+				ProfileID:     "my-profile",
+				RoutingConfig: &SingleClusterRoutingConfig{ClusterID: "cluster-1", AllowTransactionalWrites: true},
+			},
+		},
+		{
+			desc:    "multi cluster without affinity",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").WithMultiCluster("cluster-1", "cluster-2"),
+			want: ProfileConf{
+				InstanceID:    "my-instance",
+				ProfileID:     "my-profile",
+				RoutingConfig: &MultiClusterRoutingUseAnyConfig{ClusterIDs: []string{"cluster-1", "cluster-2"}},
+			},
+		},
+		{
+			desc: "multi cluster with row affinity",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").
+				WithMultiCluster("cluster-1", "cluster-2").
+				WithRowAffinity(),
+			want: ProfileConf{
+				InstanceID: "my-instance",
+				ProfileID:  "my-profile",
+				RoutingConfig: &MultiClusterRoutingUseAnyConfig{
+					ClusterIDs: []string{"cluster-1", "cluster-2"},
+					Affinity:   &RowAffinity{},
+				},
+			},
+		},
+		{
+			desc: "standard isolation",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").
+				WithSingleCluster("cluster-1", false).
+				WithStandardIsolation(AppProfilePriorityHigh),
+			want: ProfileConf{
+				InstanceID:    "my-instance",
+				ProfileID:     "my-profile",
+				RoutingConfig: &SingleClusterRoutingConfig{ClusterID: "cluster-1"},
+				Isolation:     &StandardIsolation{Priority: AppProfilePriorityHigh},
+			},
+		},
+		{
+			desc: "data boost read only",
+			builder: NewAppProfileBuilder("my-instance", "my-profile").
+				WithSingleCluster("cluster-1", false).
+				WithDataBoost(HostPays),
+			want: ProfileConf{
+				InstanceID:    "my-instance",
+				ProfileID:     "my-profile",
+				RoutingConfig: &SingleClusterRoutingConfig{ClusterID: "cluster-1"},
+				Isolation:     &DataBoostIsolationReadOnly{ComputeBillingOwner: HostPays},
+			},
+		},
+	} {
+		got, err := test.builder.Build()
+		if err != nil {
+			t.Errorf("%s: Build got unexpected error: %v", test.desc, err)
+			continue
+		}
+		if !profileConfEqual(got, test.want) {
+			t.Errorf("%s: Build got %+v, want %+v", test.desc, got, test.want)
+		}
+	}
+}
+
+// profileConfEqual compares the fields an AppProfileBuilder can populate,
+// other than IgnoreWarnings. RoutingConfig and Isolation are compared by
+// concrete type and field; for Affinity it checks only that both or
+// neither have one set.
+func profileConfEqual(a, b ProfileConf) bool {
+	if a.InstanceID != b.InstanceID || a.ProfileID != b.ProfileID || a.Description != b.Description || a.Etag != b.Etag {
+		return false
+	}
+	return routingPolicyConfigEqual(a.RoutingConfig, b.RoutingConfig) && appProfileIsolationEqual(a.Isolation, b.Isolation)
+}
+
+func routingPolicyConfigEqual(a, b RoutingPolicyConfig) bool {
+	switch av := a.(type) {
+	case *SingleClusterRoutingConfig:
+		bv, ok := b.(*SingleClusterRoutingConfig)
+		return ok && *av == *bv
+	case *MultiClusterRoutingUseAnyConfig:
+		bv, ok := b.(*MultiClusterRoutingUseAnyConfig)
+		if !ok || len(av.ClusterIDs) != len(bv.ClusterIDs) {
+			return false
+		}
+		for i := range av.ClusterIDs {
+			if av.ClusterIDs[i] != bv.ClusterIDs[i] {
+				return false
+			}
+		}
+		return av.Affinity == bv.Affinity || (av.Affinity != nil && bv.Affinity != nil)
+	default:
+		return a == nil && b == nil
+	}
+}
+
+func appProfileIsolationEqual(a, b AppProfileIsolation) bool {
+	switch av := a.(type) {
+	case *StandardIsolation:
+		bv, ok := b.(*StandardIsolation)
+		return ok && *av == *bv
+	case *DataBoostIsolationReadOnly:
+		bv, ok := b.(*DataBoostIsolationReadOnly)
+		return ok && *av == *bv
+	default:
+		return a == nil && b == nil
+	}
+}
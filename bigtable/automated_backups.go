@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/internal/optional"
+	gax "github.com/googleapis/gax-go/v2"
+)
+
+// automatedBackupNamePrefix is the prefix Cloud Bigtable gives the backups
+// it creates itself under a table's TableAutomatedBackupPolicy, as
+// opposed to backups an operator made directly with CreateBackup.
+const automatedBackupNamePrefix = "_automated_backup_"
+
+// automatedBackup pairs a BackupInfo with the cluster it lives in, since
+// listAutomatedBackupsForTable lists across every cluster in the
+// instance and BackupInfo itself doesn't retain that.
+type automatedBackup struct {
+	info    *BackupInfo
+	cluster string
+}
+
+// listAutomatedBackupsForTable returns every automated backup of tableID
+// across every cluster in the instance, in no particular order.
+func (ac *AdminClient) listAutomatedBackupsForTable(ctx context.Context, tableID string) ([]automatedBackup, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	req := &btapb.ListBackupsRequest{Parent: ac.instancePrefix() + "/clusters/-"}
+
+	var out []automatedBackup
+	for {
+		var resp *btapb.ListBackupsResponse
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+			var err error
+			resp, err = ac.tClient.ListBackups(ctx, req)
+			return err
+		}, adminRetryOptions...)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range resp.Backups {
+			info, err := newBackupInfo(b)
+			if err != nil {
+				return nil, err
+			}
+			if info.SourceTable != tableID || !strings.HasPrefix(info.Name, automatedBackupNamePrefix) {
+				continue
+			}
+			parts := strings.Split(b.Name, "/")
+			out = append(out, automatedBackup{info: info, cluster: parts[len(parts)-3]})
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	return out, nil
+}
+
+// ListAutomatedBackups returns the backups Cloud Bigtable has made for
+// tableID under its TableAutomatedBackupPolicy (see
+// TableConf.AutomatedBackupConfig), across every cluster in the instance,
+// newest first. Backups an operator made directly with CreateBackup are
+// not included.
+func (ac *AdminClient) ListAutomatedBackups(ctx context.Context, tableID string) ([]*BackupInfo, error) {
+	backups, err := ac.listAutomatedBackupsForTable(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*BackupInfo, len(backups))
+	for i, b := range backups {
+		infos[i] = b.info
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].StartTime.After(infos[j].StartTime) })
+	return infos, nil
+}
+
+// RestoreTableFromAutomatedBackup restores newTableID from the newest
+// automated backup of sourceTableID whose creation time is at or before
+// at, searching across every cluster in the instance.
+func (ac *AdminClient) RestoreTableFromAutomatedBackup(ctx context.Context, newTableID, sourceTableID string, at time.Time) error {
+	backups, err := ac.listAutomatedBackupsForTable(ctx, sourceTableID)
+	if err != nil {
+		return err
+	}
+	var best *automatedBackup
+	for i, b := range backups {
+		if b.info.StartTime.After(at) {
+			continue
+		}
+		if best == nil || b.info.StartTime.After(best.info.StartTime) {
+			best = &backups[i]
+		}
+	}
+	if best == nil {
+		return fmt.Errorf("bigtable: no automated backup of table %q at or before %s", sourceTableID, at)
+	}
+	return ac.RestoreTable(ctx, newTableID, best.cluster, best.info.Name)
+}
+
+// PruneAutomatedBackups deletes every automated backup of tableID except
+// the keep newest, across every cluster in the instance.
+func (ac *AdminClient) PruneAutomatedBackups(ctx context.Context, tableID string, keep int) error {
+	backups, err := ac.listAutomatedBackupsForTable(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].info.StartTime.After(backups[j].info.StartTime) })
+	if keep < 0 {
+		keep = 0
+	}
+	if keep >= len(backups) {
+		return nil
+	}
+	for _, b := range backups[keep:] {
+		if err := ac.DeleteBackup(ctx, b.cluster, b.info.Name); err != nil {
+			return fmt.Errorf("bigtable: pruning backup %q: %w", b.info.Name, err)
+		}
+	}
+	return nil
+}
+
+// maxAutomatedBackupRetentionPeriod is the longest RetentionPeriod Cloud
+// Bigtable currently accepts for a TableAutomatedBackupPolicy.
+const maxAutomatedBackupRetentionPeriod = 90 * 24 * time.Hour
+
+// Validate reports an error if abp isn't a policy Cloud Bigtable will
+// accept: Frequency must be at least 24 hours, the shortest interval
+// Cloud Bigtable currently supports; RetentionPeriod must be at least
+// Frequency and no more than maxAutomatedBackupRetentionPeriod; and
+// RetentionPeriod must be an exact multiple of Frequency, since Cloud
+// Bigtable prunes automated backups on Frequency-aligned boundaries and
+// rejects a retention window that would leave a partial period.
+func (abp *TableAutomatedBackupPolicy) Validate() error {
+	if abp == nil {
+		return nil
+	}
+	var freq, retention time.Duration
+	if abp.Frequency != nil {
+		freq = optional.ToDuration(abp.Frequency)
+		if freq < 24*time.Hour {
+			return fmt.Errorf("bigtable: automated backup Frequency must be at least 24h, got %s", freq)
+		}
+	}
+	if abp.RetentionPeriod != nil {
+		retention = optional.ToDuration(abp.RetentionPeriod)
+		if retention > maxAutomatedBackupRetentionPeriod {
+			return fmt.Errorf("bigtable: automated backup RetentionPeriod must be at most %s, got %s", maxAutomatedBackupRetentionPeriod, retention)
+		}
+	}
+	if abp.Frequency != nil && abp.RetentionPeriod != nil {
+		if retention < freq {
+			return fmt.Errorf("bigtable: automated backup RetentionPeriod (%s) must be at least Frequency (%s)", retention, freq)
+		}
+		if retention%freq != 0 {
+			return fmt.Errorf("bigtable: automated backup RetentionPeriod (%s) must be an exact multiple of Frequency (%s)", retention, freq)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// backupPolicyNamePrefix identifies the backups ApplyBackupPolicies
+// creates and manages for a BackupPolicy, distinct from
+// scheduledBackupNamePrefix/backupPlanNamePrefix's BackupSchedule/
+// TableBackupPlan goroutine-driven reconcilers: ApplyBackupPolicies keeps
+// no in-process state at all, so every call re-derives what it owns from
+// AdminClient.Backups, the same way those do, but is meant to be safe to
+// invoke cold from a cron job or Cloud Function rather than run forever
+// in a goroutine.
+const backupPolicyNamePrefix = "_backup_policy_"
+
+// BackupPolicy declaratively configures one table's backup lifecycle for
+// ApplyBackupPolicies: when to create backups, how long to keep them, and
+// where to copy them for disaster recovery.
+type BackupPolicy struct {
+	// Table is the source table this policy backs up.
+	Table string
+	// Cluster is the cluster new backups are created in.
+	Cluster string
+	// Every is how often a new backup is due. Required.
+	Every time.Duration
+	// NameTemplate is a time.Format layout used to render the
+	// time-varying part of each backup's name from the period it was
+	// created for (e.g. "2006-01-02-150405" for a human-readable
+	// timestamp). Defaults to "20060102-150405". The name always also
+	// carries an internal prefix and Table, so ApplyBackupPolicies can
+	// tell its own backups apart from others on the next call.
+	NameTemplate string
+	// BackupType is the type of backup to create. Defaults to
+	// BackupTypeStandard.
+	BackupType BackupType
+	// HotToStandardAfter, for a BackupTypeHot policy, is how long after
+	// creation a backup is converted to a standard backup. Zero means
+	// backups stay hot for their full lifetime. Ignored for
+	// BackupTypeStandard.
+	HotToStandardAfter time.Duration
+	// RetainFor is how long a backup is kept, measured from its start
+	// time, before ApplyBackupPolicies deletes it. Zero means retention
+	// is governed by MaxBackups alone; at least one of RetainFor or
+	// MaxBackups is required.
+	RetainFor time.Duration
+	// MaxBackups caps how many of this policy's backups may exist at
+	// once; ApplyBackupPolicies deletes the oldest past the cap even if
+	// they haven't reached RetainFor yet. Zero means no cap.
+	MaxBackups int
+	// CopyDestinations, if set, are copied to via AdminClient.CopyBackups
+	// once per backup ApplyBackupPolicies creates for this policy, for
+	// cross-cluster or cross-project disaster recovery. Since
+	// ApplyBackupPolicies keeps no state between calls, it treats a
+	// destination that already has a backup named Destination.BackupName
+	// as already copied and won't start it again.
+	CopyDestinations []CopyBackupDestination
+}
+
+func (p BackupPolicy) validate() error {
+	if p.Table == "" {
+		return errors.New("bigtable: BackupPolicy.Table is required")
+	}
+	if p.Cluster == "" {
+		return errors.New("bigtable: BackupPolicy.Cluster is required")
+	}
+	if p.Every <= 0 {
+		return errors.New("bigtable: BackupPolicy.Every must be positive")
+	}
+	if p.RetainFor <= 0 && p.MaxBackups <= 0 {
+		return errors.New("bigtable: BackupPolicy requires at least one of RetainFor or MaxBackups")
+	}
+	if p.BackupType == BackupTypeHot && p.RetainFor > 0 && p.HotToStandardAfter >= p.RetainFor {
+		return errors.New("bigtable: BackupPolicy.HotToStandardAfter must be less than RetainFor")
+	}
+	return nil
+}
+
+func (p BackupPolicy) nameTemplate() string {
+	if p.NameTemplate != "" {
+		return p.NameTemplate
+	}
+	return "20060102-150405"
+}
+
+// backupPolicyBackupName deterministically names the backup due for
+// period under p, so the same period always produces the same name: a
+// repeated or resumed-after-crash call to ApplyBackupPolicies that lands
+// on the same period can't create a duplicate.
+func backupPolicyBackupName(p BackupPolicy, period time.Time) string {
+	return fmt.Sprintf("%s%s_%s", backupPolicyNamePrefix, p.Table, period.Format(p.nameTemplate()))
+}
+
+// BackupPolicyAction describes one step an ApplyBackupPolicies call took
+// (or tried to take) against a BackupPolicy's backups.
+type BackupPolicyAction struct {
+	// Verb is "create", "delete", "transition", or "copy".
+	Verb       string
+	BackupName string
+	// Destination is set when Verb is "copy".
+	Destination CopyBackupDestination
+	// Err is set if this action failed; the action is still listed so a
+	// caller can see what ApplyBackupPolicies attempted.
+	Err error
+}
+
+// BackupPolicyReport summarizes the actions one ApplyBackupPolicies call
+// took for a single BackupPolicy, mirroring UpdateInstanceResults for the
+// instance/cluster reconciler.
+type BackupPolicyReport struct {
+	Table   string
+	Actions []BackupPolicyAction
+}
+
+func (r *BackupPolicyReport) String() string {
+	var created, deleted, transitioned, copied, failed int
+	for _, a := range r.Actions {
+		if a.Err != nil {
+			failed++
+			continue
+		}
+		switch a.Verb {
+		case "create":
+			created++
+		case "delete":
+			deleted++
+		case "transition":
+			transitioned++
+		case "copy":
+			copied++
+		}
+	}
+	return fmt.Sprintf("table %q: %d created, %d deleted, %d transitioned, %d copies started, %d failed",
+		r.Table, created, deleted, transitioned, copied, failed)
+}
+
+// Errs returns every action's non-nil Err, for a caller that wants a
+// single error value to check or wrap, joined with errors.Join.
+func (r *BackupPolicyReport) Errs() error {
+	var errs []error
+	for _, a := range r.Actions {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", a.Verb, a.BackupName, a.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyBackupPolicies runs one reconciliation pass for each policy: it
+// creates the policy's next backup if due, deletes backups beyond
+// MaxBackups or past RetainFor, schedules hot-to-standard conversions,
+// and starts any outstanding CopyDestinations copies. Unlike
+// AdminClient.CreateBackupSchedule or UpdateTableWithBackupPlan,
+// ApplyBackupPolicies keeps no goroutine and no in-process state: every
+// call lists the current backups from scratch via AdminClient.Backups
+// (and, for CopyDestinations, AdminClient.BackupInfo at each
+// destination), so it's safe to invoke cold from a cron job or Cloud
+// Function on whatever cadence the caller chooses, independent of each
+// policy's Every.
+//
+// Every policy is attempted even if another fails to list its backups;
+// within a policy, every action is attempted even if an earlier one
+// fails, and failures are recorded on the returned BackupPolicyReport
+// rather than stopping the pass. The returned error is non-nil only if
+// every policy failed outright before any action could be attempted.
+func (ac *AdminClient) ApplyBackupPolicies(ctx context.Context, policies []BackupPolicy) ([]*BackupPolicyReport, error) {
+	reports := make([]*BackupPolicyReport, 0, len(policies))
+	var fatal []error
+	for _, policy := range policies {
+		report, err := ac.applyBackupPolicy(ctx, policy)
+		if err != nil {
+			fatal = append(fatal, fmt.Errorf("table %q: %w", policy.Table, err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 && len(fatal) > 0 {
+		return nil, errors.Join(fatal...)
+	}
+	return reports, nil
+}
+
+func (ac *AdminClient) applyBackupPolicy(ctx context.Context, policy BackupPolicy) (*BackupPolicyReport, error) {
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := ac.listBackupPolicyBackups(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BackupPolicyReport{Table: policy.Table}
+	now := time.Now()
+
+	period := now.Truncate(policy.Every)
+	name := backupPolicyBackupName(policy, period)
+	if _, ok := existing[name]; !ok {
+		expireTime := now.Add(policy.RetainFor)
+		if policy.RetainFor <= 0 {
+			// Retention is governed by MaxBackups alone; Backup still
+			// requires an ExpireTime, so give it a long one and let
+			// MaxBackups do the actual pruning below.
+			expireTime = now.Add(100 * 365 * 24 * time.Hour)
+		}
+		opts := []BackupOption{WithExpiry(expireTime)}
+		if policy.BackupType == BackupTypeHot {
+			opts = append(opts, WithHotBackup())
+		}
+		err := ac.CreateBackupWithOptions(ctx, policy.Table, policy.Cluster, name, opts...)
+		report.Actions = append(report.Actions, BackupPolicyAction{Verb: "create", BackupName: name, Err: err})
+		if err == nil {
+			existing[name] = &BackupInfo{Name: name, SourceTable: policy.Table, StartTime: now, BackupType: policy.BackupType}
+		}
+	}
+
+	if policy.BackupType == BackupTypeHot && policy.HotToStandardAfter > 0 {
+		for name, info := range existing {
+			if info.BackupType != BackupTypeHot || info.HotToStandardTime != nil {
+				continue
+			}
+			if now.Sub(info.StartTime) < policy.HotToStandardAfter {
+				continue
+			}
+			err := ac.UpdateBackupHotToStandardTime(ctx, policy.Cluster, name, now)
+			report.Actions = append(report.Actions, BackupPolicyAction{Verb: "transition", BackupName: name, Err: err})
+		}
+	}
+
+	toDelete := make(map[string]bool)
+	if policy.RetainFor > 0 {
+		for name, info := range existing {
+			if now.Sub(info.StartTime) >= policy.RetainFor {
+				toDelete[name] = true
+			}
+		}
+	}
+	if policy.MaxBackups > 0 && len(existing)-len(toDelete) > policy.MaxBackups {
+		var remaining []*BackupInfo
+		for name, info := range existing {
+			if !toDelete[name] {
+				remaining = append(remaining, info)
+			}
+		}
+		sort.Slice(remaining, func(i, j int) bool { return remaining[i].StartTime.Before(remaining[j].StartTime) })
+		for _, info := range remaining[:len(remaining)-policy.MaxBackups] {
+			toDelete[info.Name] = true
+		}
+	}
+	for name := range toDelete {
+		err := ac.DeleteBackup(ctx, policy.Cluster, name)
+		report.Actions = append(report.Actions, BackupPolicyAction{Verb: "delete", BackupName: name, Err: err})
+	}
+
+	if len(policy.CopyDestinations) > 0 {
+		for name, info := range existing {
+			if toDelete[name] {
+				continue
+			}
+			for _, dst := range policy.CopyDestinations {
+				if ac.backupPolicyDestinationExists(ctx, dst) {
+					continue
+				}
+				_, err := ac.CopyBackups(ctx, policy.Cluster, info.Name, []CopyBackupDestination{dst}, CopyBackupOptions{})
+				report.Actions = append(report.Actions, BackupPolicyAction{Verb: "copy", BackupName: info.Name, Destination: dst, Err: err})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// backupPolicyDestinationExists reports whether dst's destination backup
+// already exists, the stateless substitute for the in-memory "already
+// copied" bookkeeping UpdateTableWithBackupPlan's reconciler keeps: a
+// call to ApplyBackupPolicies that starts cold sees the same answer a
+// long-running reconciler would get from its own memory. A lookup that
+// fails with any code other than NotFound also counts as existing.
+func (ac *AdminClient) backupPolicyDestinationExists(ctx context.Context, dst CopyBackupDestination) bool {
+	_, err := ac.getBackupAt(ctx, dst.Project, dst.Instance, dst.Cluster, dst.BackupName)
+	if err == nil {
+		return true
+	}
+	return grpcstatus.Code(err) != codes.NotFound
+}
+
+// getBackupAt is BackupInfo, but for a cluster in any project/instance,
+// not just ac's own, since a CopyBackupDestination may name either.
+func (ac *AdminClient) getBackupAt(ctx context.Context, project, instance, cluster, backup string) (*BackupInfo, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	req := &btapb.GetBackupRequest{
+		Name: instancePrefix(project, instance) + "/clusters/" + cluster + "/backups/" + backup,
+	}
+	var resp *btapb.Backup
+	err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+		var err error
+		resp, err = ac.tClient.GetBackup(ctx, req)
+		return err
+	}, adminRetryOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return newBackupInfo(resp)
+}
+
+// listBackupPolicyBackups returns, keyed by name, the backups
+// ApplyBackupPolicies has previously created for policy.Table in
+// policy.Cluster, identified by backupPolicyNamePrefix the same way
+// scheduledBackupNamePrefix/backupPlanNamePrefix identify their own
+// reconcilers' backups.
+func (ac *AdminClient) listBackupPolicyBackups(ctx context.Context, policy BackupPolicy) (map[string]*BackupInfo, error) {
+	prefix := backupPolicyNamePrefix + policy.Table + "_"
+	out := make(map[string]*BackupInfo)
+	it := ac.Backups(ctx, policy.Cluster)
+	for {
+		info, err := it.Next()
+		if err == iterator.Done {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if info.SourceTable != policy.Table || !strings.HasPrefix(info.Name, prefix) {
+			continue
+		}
+		out[info.Name] = info
+	}
+}
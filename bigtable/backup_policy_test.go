@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackupPolicyValidate(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		p       BackupPolicy
+		wantErr bool
+	}{
+		{
+			desc: "ok with RetainFor",
+			p:    BackupPolicy{Table: "t1", Cluster: "c1", Every: time.Hour, RetainFor: 24 * time.Hour},
+		},
+		{
+			desc: "ok with MaxBackups",
+			p:    BackupPolicy{Table: "t1", Cluster: "c1", Every: time.Hour, MaxBackups: 3},
+		},
+		{
+			desc:    "missing Table",
+			p:       BackupPolicy{Cluster: "c1", Every: time.Hour, MaxBackups: 3},
+			wantErr: true,
+		},
+		{
+			desc:    "missing Cluster",
+			p:       BackupPolicy{Table: "t1", Every: time.Hour, MaxBackups: 3},
+			wantErr: true,
+		},
+		{
+			desc:    "Every not positive",
+			p:       BackupPolicy{Table: "t1", Cluster: "c1", MaxBackups: 3},
+			wantErr: true,
+		},
+		{
+			desc:    "neither RetainFor nor MaxBackups set",
+			p:       BackupPolicy{Table: "t1", Cluster: "c1", Every: time.Hour},
+			wantErr: true,
+		},
+		{
+			desc: "hot backup, HotToStandardAfter less than RetainFor ok",
+			p: BackupPolicy{
+				Table: "t1", Cluster: "c1", Every: time.Hour, RetainFor: 24 * time.Hour,
+				BackupType: BackupTypeHot, HotToStandardAfter: time.Hour,
+			},
+		},
+		{
+			desc: "hot backup, HotToStandardAfter at RetainFor",
+			p: BackupPolicy{
+				Table: "t1", Cluster: "c1", Every: time.Hour, RetainFor: 24 * time.Hour,
+				BackupType: BackupTypeHot, HotToStandardAfter: 24 * time.Hour,
+			},
+			wantErr: true,
+		},
+		{
+			desc: "hot backup, HotToStandardAfter past RetainFor",
+			p: BackupPolicy{
+				Table: "t1", Cluster: "c1", Every: time.Hour, RetainFor: 24 * time.Hour,
+				BackupType: BackupTypeHot, HotToStandardAfter: 48 * time.Hour,
+			},
+			wantErr: true,
+		},
+	} {
+		err := test.p.validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: validate() = %v, wantErr %v", test.desc, err, test.wantErr)
+		}
+	}
+}
+
+func TestBackupPolicyNameTemplate(t *testing.T) {
+	p := BackupPolicy{}
+	if got, want := p.nameTemplate(), "20060102-150405"; got != want {
+		t.Errorf("default nameTemplate() = %q, want %q", got, want)
+	}
+	p.NameTemplate = "2006-01-02"
+	if got, want := p.nameTemplate(), "2006-01-02"; got != want {
+		t.Errorf("custom nameTemplate() = %q, want %q", got, want)
+	}
+}
+
+func TestBackupPolicyBackupNameDeterministic(t *testing.T) {
+	p := BackupPolicy{Table: "t1"}
+	period := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+
+	got1 := backupPolicyBackupName(p, period)
+	got2 := backupPolicyBackupName(p, period)
+	if got1 != got2 {
+		t.Errorf("backupPolicyBackupName is not deterministic for the same period: %q vs %q", got1, got2)
+	}
+	want := backupPolicyNamePrefix + "t1_" + period.Format(p.nameTemplate())
+	if got1 != want {
+		t.Errorf("backupPolicyBackupName = %q, want %q", got1, want)
+	}
+
+	other := backupPolicyBackupName(p, period.Add(time.Hour))
+	if other == got1 {
+		t.Error("backupPolicyBackupName gave the same name for two different periods")
+	}
+}
+
+func TestBackupPolicyReportString(t *testing.T) {
+	r := &BackupPolicyReport{
+		Table: "t1",
+		Actions: []BackupPolicyAction{
+			{Verb: "create", BackupName: "b1"},
+			{Verb: "delete", BackupName: "b2"},
+			{Verb: "transition", BackupName: "b3"},
+			{Verb: "copy", BackupName: "b4"},
+			{Verb: "create", BackupName: "b5", Err: errors.New("boom")},
+		},
+	}
+	want := `table "t1": 1 created, 1 deleted, 1 transitioned, 1 copies started, 1 failed`
+	if got := r.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBackupPolicyReportErrs(t *testing.T) {
+	r := &BackupPolicyReport{Table: "t1"}
+	if err := r.Errs(); err != nil {
+		t.Errorf("Errs() with no failed actions = %v, want nil", err)
+	}
+
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	r.Actions = []BackupPolicyAction{
+		{Verb: "create", BackupName: "b1"},
+		{Verb: "delete", BackupName: "b2", Err: errA},
+		{Verb: "copy", BackupName: "b3", Err: errB},
+	}
+	err := r.Errs()
+	if err == nil {
+		t.Fatal("Errs() with failed actions = nil, want error")
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Errs() = %v, want it to wrap both action errors", err)
+	}
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// scheduledBackupNamePrefix is the prefix a BackupSchedule gives the
+// backups it creates, so its reconciliation loop can tell its own
+// backups apart from ones an operator or a TableAutomatedBackupPolicy
+// made, and so a deterministically-named backup acts as an idempotency
+// key: two ticks that land on the same scheduled time, whether from the
+// same scheduler or one restarted after a crash, produce the same name
+// and the second CreateBackupWithOptions call simply fails with
+// AlreadyExists, which the reconciliation loop treats as success.
+const scheduledBackupNamePrefix = "_scheduled_backup_"
+
+// ScheduleConfig configures a BackupSchedule.
+type ScheduleConfig struct {
+	// Cluster is the cluster new backups are created in.
+	Cluster string
+	// Every is how often a new backup is created. Required.
+	Every time.Duration
+	// Retention is how long a backup is kept before being deleted.
+	// Measured from the backup's start time. Required.
+	Retention time.Duration
+	// BackupType is the type of backup to create. Defaults to
+	// BackupTypeStandard.
+	BackupType BackupType
+	// HotToStandardAfter, for a BackupTypeHot schedule, is how long after
+	// creation a backup is converted to a standard backup with
+	// UpdateBackupHotToStandardTime. Zero means backups stay hot for
+	// their full Retention. Ignored for BackupTypeStandard.
+	HotToStandardAfter time.Duration
+}
+
+func (c ScheduleConfig) validate() error {
+	if c.Cluster == "" {
+		return errors.New("bigtable: ScheduleConfig.Cluster is required")
+	}
+	if c.Every <= 0 {
+		return errors.New("bigtable: ScheduleConfig.Every must be positive")
+	}
+	if c.Retention <= 0 {
+		return errors.New("bigtable: ScheduleConfig.Retention must be positive")
+	}
+	if c.BackupType == BackupTypeHot && c.HotToStandardAfter >= c.Retention {
+		return errors.New("bigtable: ScheduleConfig.HotToStandardAfter must be less than Retention")
+	}
+	return nil
+}
+
+// BackupScheduleEventType identifies the kind of lifecycle event a
+// BackupSchedule reported on its Events channel.
+type BackupScheduleEventType int
+
+const (
+	// BackupScheduleEventCreated means a new backup was created.
+	BackupScheduleEventCreated BackupScheduleEventType = iota
+	// BackupScheduleEventTransitioned means a hot backup was converted to
+	// a standard backup.
+	BackupScheduleEventTransitioned
+	// BackupScheduleEventDeleted means an expired backup was deleted.
+	BackupScheduleEventDeleted
+	// BackupScheduleEventError means a reconciliation pass failed to
+	// create, transition, or delete a backup. The schedule keeps running;
+	// the failed action is retried on the next reconciliation.
+	BackupScheduleEventError
+)
+
+// BackupScheduleEvent reports one action a BackupSchedule took, for
+// callers that want observability beyond simply letting it run.
+type BackupScheduleEvent struct {
+	Type       BackupScheduleEventType
+	BackupName string
+	Err        error
+}
+
+// BackupSchedule manages a recurring series of backups for a table,
+// created by AdminClient.CreateBackupSchedule. Its reconciliation loop
+// treats AdminClient.Backups as the source of truth: every tick it lists
+// the table's scheduled backups, creates a new one if the current period
+// doesn't have one yet, converts hot backups past HotToStandardAfter,
+// and deletes backups past Retention. Because reconciliation always
+// starts from ListBackups rather than in-memory state, a BackupSchedule
+// can be paused, resumed, or recreated after a process restart without
+// creating duplicate backups or losing track of retention.
+type BackupSchedule struct {
+	ac      *AdminClient
+	tableID string
+	conf    ScheduleConfig
+
+	events chan BackupScheduleEvent
+
+	mu     sync.Mutex
+	paused bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// CreateBackupSchedule registers and starts a BackupSchedule for tableID.
+// Only one schedule per table may be registered through a given
+// AdminClient at a time; call DeleteBackupSchedule first to replace one.
+func (ac *AdminClient) CreateBackupSchedule(ctx context.Context, tableID string, conf ScheduleConfig) (*BackupSchedule, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	ac.schedulesMu.Lock()
+	if ac.schedules == nil {
+		ac.schedules = make(map[string]*BackupSchedule)
+	}
+	if _, ok := ac.schedules[tableID]; ok {
+		ac.schedulesMu.Unlock()
+		return nil, fmt.Errorf("bigtable: a BackupSchedule already exists for table %q", tableID)
+	}
+	bs := &BackupSchedule{
+		ac:      ac,
+		tableID: tableID,
+		conf:    conf,
+		events:  make(chan BackupScheduleEvent, 16),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	ac.schedules[tableID] = bs
+	ac.schedulesMu.Unlock()
+
+	go bs.run(ctx)
+	return bs, nil
+}
+
+// ListBackupSchedules returns the BackupSchedules currently registered
+// through ac, in no particular order. It only reflects schedules created
+// by this process; it is not a view onto any server-side state.
+func (ac *AdminClient) ListBackupSchedules() []*BackupSchedule {
+	ac.schedulesMu.Lock()
+	defer ac.schedulesMu.Unlock()
+	out := make([]*BackupSchedule, 0, len(ac.schedules))
+	for _, bs := range ac.schedules {
+		out = append(out, bs)
+	}
+	return out
+}
+
+// UpdateBackupSchedule replaces the ScheduleConfig for tableID's
+// BackupSchedule. The new config takes effect starting with the next
+// reconciliation tick.
+func (ac *AdminClient) UpdateBackupSchedule(tableID string, conf ScheduleConfig) error {
+	if err := conf.validate(); err != nil {
+		return err
+	}
+	ac.schedulesMu.Lock()
+	bs, ok := ac.schedules[tableID]
+	ac.schedulesMu.Unlock()
+	if !ok {
+		return fmt.Errorf("bigtable: no BackupSchedule registered for table %q", tableID)
+	}
+	bs.mu.Lock()
+	bs.conf = conf
+	bs.mu.Unlock()
+	return nil
+}
+
+// DeleteBackupSchedule stops tableID's BackupSchedule and unregisters it.
+// Backups it already created are left in place; delete them with
+// DeleteBackup if that's not wanted.
+func (ac *AdminClient) DeleteBackupSchedule(tableID string) error {
+	ac.schedulesMu.Lock()
+	bs, ok := ac.schedules[tableID]
+	if ok {
+		delete(ac.schedules, tableID)
+	}
+	ac.schedulesMu.Unlock()
+	if !ok {
+		return fmt.Errorf("bigtable: no BackupSchedule registered for table %q", tableID)
+	}
+	bs.Stop()
+	return nil
+}
+
+// Events returns the channel BackupSchedule reports lifecycle events on.
+// The channel is closed once Stop has fully drained the schedule's
+// goroutine.
+func (bs *BackupSchedule) Events() <-chan BackupScheduleEvent {
+	return bs.events
+}
+
+// Pause suspends reconciliation until Resume is called. A tick that
+// elapses while paused is simply skipped, not queued.
+func (bs *BackupSchedule) Pause() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.paused = true
+}
+
+// Resume reverses a prior Pause.
+func (bs *BackupSchedule) Resume() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.paused = false
+}
+
+// Stop ends the schedule's reconciliation loop and closes its Events
+// channel. It does not delete any backups already created. Stop blocks
+// until the goroutine has exited.
+func (bs *BackupSchedule) Stop() {
+	bs.stopOnce.Do(func() { close(bs.stop) })
+	<-bs.done
+}
+
+func (bs *BackupSchedule) isPaused() bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.paused
+}
+
+func (bs *BackupSchedule) config() ScheduleConfig {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.conf
+}
+
+func (bs *BackupSchedule) emit(evt BackupScheduleEvent) {
+	select {
+	case bs.events <- evt:
+	default:
+		// Don't block reconciliation on a caller that isn't draining
+		// Events; the next reconciliation pass will report current state
+		// again regardless.
+	}
+}
+
+// run is the BackupSchedule's reconciliation loop. It reconciles once
+// immediately (so a restart after a crash catches up right away) and
+// then on every tick of conf.Every thereafter.
+func (bs *BackupSchedule) run(ctx context.Context) {
+	defer close(bs.done)
+	defer close(bs.events)
+
+	bs.reconcile(ctx)
+	every := bs.config().Every
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-bs.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if conf := bs.config(); conf.Every != every {
+				every = conf.Every
+				ticker.Reset(every)
+			}
+			if !bs.isPaused() {
+				bs.reconcile(ctx)
+			}
+		}
+	}
+}
+
+// reconcile lists the table's scheduled backups (the source of truth)
+// and brings them in line with the current ScheduleConfig: it creates a
+// new backup if the current period doesn't have one yet, converts hot
+// backups that have aged past HotToStandardAfter, and deletes backups
+// that have aged past Retention.
+func (bs *BackupSchedule) reconcile(ctx context.Context) {
+	conf := bs.config()
+	now := time.Now()
+
+	existing, err := bs.listScheduledBackups(ctx)
+	if err != nil {
+		bs.emit(BackupScheduleEvent{Type: BackupScheduleEventError, Err: err})
+		return
+	}
+
+	period := now.Truncate(conf.Every)
+	name := fmt.Sprintf("%s%s_%d", scheduledBackupNamePrefix, bs.tableID, period.Unix())
+	if _, ok := existing[name]; !ok {
+		opts := []BackupOption{WithExpiry(now.Add(conf.Retention))}
+		if conf.BackupType == BackupTypeHot {
+			opts = append(opts, WithHotBackup())
+		}
+		err := bs.ac.CreateBackupWithOptions(ctx, bs.tableID, conf.Cluster, name, opts...)
+		if err != nil && grpcstatus.Code(err) != codes.AlreadyExists {
+			bs.emit(BackupScheduleEvent{Type: BackupScheduleEventError, BackupName: name, Err: err})
+		} else if err == nil {
+			bs.emit(BackupScheduleEvent{Type: BackupScheduleEventCreated, BackupName: name})
+		}
+	}
+
+	for _, b := range existing {
+		age := now.Sub(b.StartTime)
+		if conf.BackupType == BackupTypeHot && conf.HotToStandardAfter > 0 &&
+			b.BackupType == BackupTypeHot && b.HotToStandardTime == nil && age >= conf.HotToStandardAfter {
+			if err := bs.ac.UpdateBackupHotToStandardTime(ctx, conf.Cluster, b.Name, now); err != nil {
+				bs.emit(BackupScheduleEvent{Type: BackupScheduleEventError, BackupName: b.Name, Err: err})
+			} else {
+				bs.emit(BackupScheduleEvent{Type: BackupScheduleEventTransitioned, BackupName: b.Name})
+			}
+		}
+		if age >= conf.Retention {
+			if err := bs.ac.DeleteBackup(ctx, conf.Cluster, b.Name); err != nil && grpcstatus.Code(err) != codes.NotFound {
+				bs.emit(BackupScheduleEvent{Type: BackupScheduleEventError, BackupName: b.Name, Err: err})
+			} else {
+				bs.emit(BackupScheduleEvent{Type: BackupScheduleEventDeleted, BackupName: b.Name})
+			}
+		}
+	}
+}
+
+// listScheduledBackups returns, keyed by name, the backups this
+// BackupSchedule created for its table across every cluster in the
+// instance (ListBackups is the source of truth, not any in-memory
+// bookkeeping).
+func (bs *BackupSchedule) listScheduledBackups(ctx context.Context) (map[string]*BackupInfo, error) {
+	ac := bs.ac
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	req := &btapb.ListBackupsRequest{Parent: ac.instancePrefix() + "/clusters/-"}
+
+	out := make(map[string]*BackupInfo)
+	for {
+		var resp *btapb.ListBackupsResponse
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+			var err error
+			resp, err = ac.tClient.ListBackups(ctx, req)
+			return err
+		}, adminRetryOptions...)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range resp.Backups {
+			info, err := newBackupInfo(b)
+			if err != nil {
+				return nil, err
+			}
+			if info.SourceTable != bs.tableID || !strings.HasPrefix(info.Name, scheduledBackupNamePrefix) {
+				continue
+			}
+			out[info.Name] = info
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	return out, nil
+}
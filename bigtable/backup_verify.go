@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// VerifyBackupOptions configures AdminClient.VerifyBackup.
+type VerifyBackupOptions struct {
+	// DataClient reads rows from the restored and source tables while
+	// computing their checksums. Required: AdminClient only holds an
+	// admin connection, not a data-plane one.
+	DataClient *Client
+
+	// RestoredTable, if set, names a table VerifyBackup treats as
+	// already restored from backup, instead of restoring one itself.
+	// Useful when a restore was already done ahead of time, or when
+	// several backups should be verified against one long-lived restored
+	// table. If empty, VerifyBackup restores backup into a temporary
+	// table and deletes it once verification finishes.
+	RestoredTable string
+
+	// Parallelism caps how many shards of each table (restored and
+	// source) are checksummed concurrently; see ChecksumOptions.
+	Parallelism int
+}
+
+// ShardMismatch reports that a restored backup's checksum didn't match
+// the source table's over one shard of the key space.
+type ShardMismatch struct {
+	Range                        RowRange
+	RestoredDigest, SourceDigest [sha256.Size]byte
+}
+
+// BackupVerification is the result of AdminClient.VerifyBackup.
+type BackupVerification struct {
+	Match                        bool
+	RestoredDigest, SourceDigest [sha256.Size]byte
+	// Mismatches names the shards (by key range) whose checksums
+	// disagreed, for triage. Empty when Match is true.
+	Mismatches []ShardMismatch
+}
+
+// VerifyBackup checks that backup restores to the same data its source
+// table had at the backup's StartTime, by restoring it (into
+// opts.RestoredTable if set, or a temporary table otherwise) and
+// comparing a ChecksumTable digest of the restored table against one of
+// the source table.
+//
+// "At the backup's StartTime" is only approximated: this package has no
+// point-in-time read API for a live table, so VerifyBackup checksums the
+// source table with a TimestampRangeFilter excluding cells written after
+// StartTime. A cell the source table has since deleted or garbage
+// collected won't show up in that checksum even though it predates
+// StartTime, which can report a mismatch against a perfectly good backup;
+// treat a mismatch as a reason to inspect Mismatches; not, on its own,
+// proof the backup is corrupt.
+func (ac *AdminClient) VerifyBackup(ctx context.Context, cluster, backup string, opts VerifyBackupOptions) (*BackupVerification, error) {
+	if opts.DataClient == nil {
+		return nil, errors.New("bigtable: VerifyBackupOptions.DataClient is required")
+	}
+
+	info, err := ac.BackupInfo(ctx, cluster, backup)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: looking up backup %q: %w", backup, err)
+	}
+
+	tableID := opts.RestoredTable
+	if tableID == "" {
+		tableID = fmt.Sprintf("_verify_%s_%d", backup, time.Now().UnixNano())
+		if err := ac.RestoreTable(ctx, tableID, cluster, backup); err != nil {
+			return nil, fmt.Errorf("bigtable: restoring backup %q for verification: %w", backup, err)
+		}
+		defer ac.DeleteTable(ctx, tableID)
+	}
+
+	restoredChecksum, err := opts.DataClient.Open(tableID).ChecksumTable(ctx, ChecksumOptions{Parallelism: opts.Parallelism})
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: checksumming restored table %q: %w", tableID, err)
+	}
+	sourceChecksum, err := opts.DataClient.Open(info.SourceTable).ChecksumTable(ctx, ChecksumOptions{
+		Parallelism: opts.Parallelism,
+		Filter:      TimestampRangeFilter(time.Time{}, info.StartTime),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: checksumming source table %q: %w", info.SourceTable, err)
+	}
+
+	result := &BackupVerification{
+		RestoredDigest: restoredChecksum.Digest,
+		SourceDigest:   sourceChecksum.Digest,
+		Match:          restoredChecksum.Digest == sourceChecksum.Digest,
+	}
+	if !result.Match {
+		result.Mismatches = mismatchedShards(restoredChecksum, sourceChecksum)
+	}
+	return result, nil
+}
+
+// mismatchedShards pairs up restored's and source's shards by key range
+// (both were computed by ChecksumTable's own SampleRowKeys-based
+// sharding, so ranges only line up exactly when both tables happen to
+// share the same tablet boundaries) and reports every range whose
+// digests disagree or that only one side has.
+func mismatchedShards(restored, source *TableChecksum) []ShardMismatch {
+	bySourceRange := make(map[RowRange]ShardChecksum, len(source.Shards))
+	for _, s := range source.Shards {
+		bySourceRange[s.Range] = s
+	}
+
+	seen := make(map[RowRange]bool, len(source.Shards))
+	var mismatches []ShardMismatch
+	for _, r := range restored.Shards {
+		seen[r.Range] = true
+		s, ok := bySourceRange[r.Range]
+		if !ok || r.Digest != s.Digest {
+			mismatches = append(mismatches, ShardMismatch{Range: r.Range, RestoredDigest: r.Digest, SourceDigest: s.Digest})
+		}
+	}
+	for _, s := range source.Shards {
+		if seen[s.Range] {
+			continue
+		}
+		mismatches = append(mismatches, ShardMismatch{Range: s.Range, SourceDigest: s.Digest})
+	}
+	return mismatches
+}
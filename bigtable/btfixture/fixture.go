@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package btfixture declares Bigtable test data as plain Go structs instead
+// of hand-rolled CreateColumnFamily/Apply loops. A Fixture seeds a table
+// through the public client, and Golden/DiffRows read it back and compare
+// it against other test runs with timestamps normalized out of the way.
+package btfixture
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigtable"
+	"github.com/google/go-cmp/cmp"
+)
+
+// Cell is a single family/qualifier/timestamp value to seed into a row.
+type Cell struct {
+	Family    string
+	Qualifier string
+	Timestamp bigtable.Timestamp
+	Value     []byte
+}
+
+// Row is the set of cells to seed for one row key.
+type Row struct {
+	Key   string
+	Cells []Cell
+}
+
+// Fixture is a self-contained set of Bigtable test data: the column
+// families a table needs (including their GC policy or aggregate value
+// type) and the rows to populate once those families exist.
+type Fixture struct {
+	// Families maps family name to the Family config CreateColumnFamily
+	// should use, e.g. bigtable.Family{ValueType: bigtable.AggregateType{...}}.
+	Families map[string]bigtable.Family
+	Rows     []Row
+}
+
+// Provision creates every column family declared in f on tableID using ac,
+// so a single Fixture value can fully set up an empty table for a test.
+// Families that already exist are left untouched.
+func (f Fixture) Provision(ctx context.Context, ac *bigtable.AdminClient, tableID string) error {
+	ti, err := ac.TableInfo(ctx, tableID)
+	if err != nil {
+		return fmt.Errorf("btfixture: reading table %q: %w", tableID, err)
+	}
+	existing := make(map[string]bool, len(ti.Families))
+	for _, name := range ti.Families {
+		existing[name] = true
+	}
+	for name, conf := range f.Families {
+		if existing[name] {
+			continue
+		}
+		if err := ac.CreateColumnFamilyWithConfig(ctx, tableID, name, conf); err != nil {
+			return fmt.Errorf("btfixture: creating family %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Seed applies every row in f to table.
+func (f Fixture) Seed(ctx context.Context, table *bigtable.Table) error {
+	for _, r := range f.Rows {
+		mut := bigtable.NewMutation()
+		for _, c := range r.Cells {
+			mut.Set(c.Family, c.Qualifier, c.Timestamp, c.Value)
+		}
+		if err := table.Apply(ctx, r.Key, mut); err != nil {
+			return fmt.Errorf("btfixture: seeding row %q: %w", r.Key, err)
+		}
+	}
+	return nil
+}
+
+// Golden reads back row key from table and returns it with all cell
+// timestamps cleared, so the result can be compared against an expected
+// bigtable.Row literal without hard-coding server-assigned timestamps.
+func Golden(ctx context.Context, table *bigtable.Table, key string) (bigtable.Row, error) {
+	row, err := table.ReadRow(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("btfixture: reading golden row %q: %w", key, err)
+	}
+	clearTimestamps(row)
+	return row, nil
+}
+
+func clearTimestamps(r bigtable.Row) {
+	for _, ris := range r {
+		for i := range ris {
+			ris[i].Timestamp = 0
+		}
+	}
+}
+
+// DiffRows normalizes timestamps on both got and want, then returns a
+// human-readable diff (empty if they're equal), suitable for t.Error(diff).
+func DiffRows(got, want bigtable.Row) string {
+	gotCopy := cloneRow(got)
+	wantCopy := cloneRow(want)
+	clearTimestamps(gotCopy)
+	clearTimestamps(wantCopy)
+	return cmp.Diff(wantCopy, gotCopy)
+}
+
+func cloneRow(r bigtable.Row) bigtable.Row {
+	out := make(bigtable.Row, len(r))
+	for fam, ris := range r {
+		cp := make([]bigtable.ReadItem, len(ris))
+		copy(cp, ris)
+		out[fam] = cp
+	}
+	return out
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bttest
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This file implements the subset of AuthorizedView and SchemaBundle
+// admin RPCs that are scoped to a table, so they fit the
+// btapb.BigtableTableAdminServer this fake already registers (see
+// NewServer in inmem.go). LogicalView and MaterializedView are
+// instance-scoped resources served by BigtableInstanceAdminServer, which
+// this fake doesn't implement at all yet, so they're left out here
+// rather than bolted onto the wrong service.
+//
+// Enforcing an AuthorizedView's row/family/qualifier restrictions on the
+// data-path RPCs in data.go (ReadRows, MutateRow, ...) is also left out:
+// doing so requires knowing which field of those requests a real client
+// populates when it targets an authorized view instead of a table, and
+// that isn't visible anywhere in this checkout to confirm against.
+
+// CreateAuthorizedView implements btapb.BigtableTableAdminServer.
+func (s *server) CreateAuthorizedView(ctx context.Context, req *btapb.CreateAuthorizedViewRequest) (*btapb.AuthorizedView, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Parent]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Parent)
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	if _, ok := tbl.authorizedViews[req.AuthorizedViewId]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "authorized view %q already exists", req.AuthorizedViewId)
+	}
+	name := req.Parent + "/authorizedViews/" + req.AuthorizedViewId
+	av := proto.Clone(req.GetAuthorizedView()).(*btapb.AuthorizedView)
+	av.Name = name
+	tbl.authorizedViews[req.AuthorizedViewId] = av
+	return av, nil
+}
+
+// GetAuthorizedView implements btapb.BigtableTableAdminServer.
+func (s *server) GetAuthorizedView(ctx context.Context, req *btapb.GetAuthorizedViewRequest) (*btapb.AuthorizedView, error) {
+	tbl, id, err := s.authorizedViewTable(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	tbl.mu.RLock()
+	defer tbl.mu.RUnlock()
+	av, ok := tbl.authorizedViews[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "authorized view %q not found", req.Name)
+	}
+	return av, nil
+}
+
+// ListAuthorizedViews implements btapb.BigtableTableAdminServer.
+func (s *server) ListAuthorizedViews(ctx context.Context, req *btapb.ListAuthorizedViewsRequest) (*btapb.ListAuthorizedViewsResponse, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Parent]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Parent)
+	}
+
+	tbl.mu.RLock()
+	defer tbl.mu.RUnlock()
+	res := &btapb.ListAuthorizedViewsResponse{}
+	for _, av := range tbl.authorizedViews {
+		res.AuthorizedViews = append(res.AuthorizedViews, av)
+	}
+	return res, nil
+}
+
+// UpdateAuthorizedView implements btapb.BigtableTableAdminServer.
+//
+// The real RPC returns a long-running operation; this fake applies the
+// update synchronously and returns an already-done LRO, matching how
+// CreateTable and friends above skip the async machinery entirely.
+func (s *server) UpdateAuthorizedView(ctx context.Context, req *btapb.UpdateAuthorizedViewRequest) (*longrunningpb.Operation, error) {
+	tbl, id, err := s.authorizedViewTable(req.GetAuthorizedView().GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	av, ok := tbl.authorizedViews[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "authorized view %q not found", req.GetAuthorizedView().GetName())
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"subset_view", "deletion_protection"}
+	}
+	updated := proto.Clone(av).(*btapb.AuthorizedView)
+	for _, p := range paths {
+		switch p {
+		case "deletion_protection":
+			updated.DeletionProtection = req.GetAuthorizedView().GetDeletionProtection()
+		case "subset_view":
+			updated.AuthorizedView = req.GetAuthorizedView().GetAuthorizedView()
+		}
+	}
+	tbl.authorizedViews[id] = updated
+
+	return doneOperation(updated)
+}
+
+// DeleteAuthorizedView implements btapb.BigtableTableAdminServer.
+func (s *server) DeleteAuthorizedView(ctx context.Context, req *btapb.DeleteAuthorizedViewRequest) (*emptypb.Empty, error) {
+	tbl, id, err := s.authorizedViewTable(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	av, ok := tbl.authorizedViews[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "authorized view %q not found", req.Name)
+	}
+	if av.GetDeletionProtection() {
+		return nil, status.Errorf(codes.FailedPrecondition, "authorized view %q is deletion protected", req.Name)
+	}
+	delete(tbl.authorizedViews, id)
+	return &emptypb.Empty{}, nil
+}
+
+// authorizedViewTable resolves name (".../tables/T/authorizedViews/V")
+// to its owning table and the bare view ID V.
+func (s *server) authorizedViewTable(name string) (*table, string, error) {
+	parent, id, ok := splitChildResource(name, "/authorizedViews/")
+	if !ok {
+		return nil, "", status.Errorf(codes.InvalidArgument, "malformed authorized view name %q", name)
+	}
+	s.mu.Lock()
+	tbl, ok := s.tables[parent]
+	s.mu.Unlock()
+	if !ok {
+		return nil, "", status.Errorf(codes.NotFound, "table %q not found", parent)
+	}
+	return tbl, id, nil
+}
+
+// CreateSchemaBundle implements btapb.BigtableTableAdminServer.
+func (s *server) CreateSchemaBundle(ctx context.Context, req *btapb.CreateSchemaBundleRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Parent]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Parent)
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	if _, ok := tbl.schemaBundles[req.SchemaBundleId]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "schema bundle %q already exists", req.SchemaBundleId)
+	}
+	name := req.Parent + "/schemaBundles/" + req.SchemaBundleId
+	sb := proto.Clone(req.GetSchemaBundle()).(*btapb.SchemaBundle)
+	sb.Name = name
+	sb.Etag = newEtag()
+	tbl.schemaBundles[req.SchemaBundleId] = sb
+
+	return doneOperation(sb)
+}
+
+// GetSchemaBundle implements btapb.BigtableTableAdminServer.
+func (s *server) GetSchemaBundle(ctx context.Context, req *btapb.GetSchemaBundleRequest) (*btapb.SchemaBundle, error) {
+	tbl, id, err := s.schemaBundleTable(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	tbl.mu.RLock()
+	defer tbl.mu.RUnlock()
+	sb, ok := tbl.schemaBundles[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "schema bundle %q not found", req.Name)
+	}
+	return sb, nil
+}
+
+// ListSchemaBundles implements btapb.BigtableTableAdminServer.
+func (s *server) ListSchemaBundles(ctx context.Context, req *btapb.ListSchemaBundlesRequest) (*btapb.ListSchemaBundlesResponse, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Parent]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Parent)
+	}
+
+	tbl.mu.RLock()
+	defer tbl.mu.RUnlock()
+	res := &btapb.ListSchemaBundlesResponse{}
+	for _, sb := range tbl.schemaBundles {
+		res.SchemaBundles = append(res.SchemaBundles, sb)
+	}
+	return res, nil
+}
+
+// UpdateSchemaBundle implements btapb.BigtableTableAdminServer.
+func (s *server) UpdateSchemaBundle(ctx context.Context, req *btapb.UpdateSchemaBundleRequest) (*longrunningpb.Operation, error) {
+	tbl, id, err := s.schemaBundleTable(req.GetSchemaBundle().GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	sb, ok := tbl.schemaBundles[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "schema bundle %q not found", req.GetSchemaBundle().GetName())
+	}
+	if req.GetSchemaBundle().GetEtag() != "" && req.GetSchemaBundle().GetEtag() != sb.Etag {
+		return nil, status.Errorf(codes.FailedPrecondition, "schema bundle %q etag mismatch", req.GetSchemaBundle().GetName())
+	}
+
+	updated := proto.Clone(sb).(*btapb.SchemaBundle)
+	for _, p := range req.GetUpdateMask().GetPaths() {
+		if p == "proto_schema" {
+			updated.Type = req.GetSchemaBundle().GetType()
+		}
+	}
+	updated.Etag = newEtag()
+	tbl.schemaBundles[id] = updated
+
+	return doneOperation(updated)
+}
+
+// DeleteSchemaBundle implements btapb.BigtableTableAdminServer.
+func (s *server) DeleteSchemaBundle(ctx context.Context, req *btapb.DeleteSchemaBundleRequest) (*emptypb.Empty, error) {
+	tbl, id, err := s.schemaBundleTable(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	if _, ok := tbl.schemaBundles[id]; !ok {
+		return nil, status.Errorf(codes.NotFound, "schema bundle %q not found", req.Name)
+	}
+	delete(tbl.schemaBundles, id)
+	return &emptypb.Empty{}, nil
+}
+
+// schemaBundleTable resolves name (".../tables/T/schemaBundles/B") to its
+// owning table and the bare bundle ID B.
+func (s *server) schemaBundleTable(name string) (*table, string, error) {
+	parent, id, ok := splitChildResource(name, "/schemaBundles/")
+	if !ok {
+		return nil, "", status.Errorf(codes.InvalidArgument, "malformed schema bundle name %q", name)
+	}
+	s.mu.Lock()
+	tbl, ok := s.tables[parent]
+	s.mu.Unlock()
+	if !ok {
+		return nil, "", status.Errorf(codes.NotFound, "table %q not found", parent)
+	}
+	return tbl, id, nil
+}
+
+// etagCounter backs newEtag; a monotonic counter is all a fake needs to
+// hand out values UpdateSchemaBundle's optimistic-concurrency check can
+// compare against, without pulling in a random source.
+var etagCounter uint64
+
+func newEtag() string {
+	return strconv.FormatUint(atomic.AddUint64(&etagCounter, 1), 10)
+}
+
+// doneOperation packages resp as an already-completed
+// longrunningpb.Operation, the way a real LRO-returning RPC would look
+// once polling caught up to it. longrunning.InternalNewOperation(...).Wait
+// checks Done and unpacks Result before ever issuing a GetOperation poll,
+// so a synthetic always-done Operation is enough to satisfy callers; this
+// fake doesn't implement the longrunning.Operations service itself.
+func doneOperation(resp proto.Message) (*longrunningpb.Operation, error) {
+	any, err := anypb.New(resp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "packing operation response: %v", err)
+	}
+	return &longrunningpb.Operation{
+		Done:   true,
+		Result: &longrunningpb.Operation_Response{Response: any},
+	}, nil
+}
+
+// splitChildResource splits name at the last occurrence of sep into its
+// parent resource and child ID, e.g.
+// splitChildResource(".../tables/t/authorizedViews/v", "/authorizedViews/")
+// returns (".../tables/t", "v", true).
+func splitChildResource(name, sep string) (parent, id string, ok bool) {
+	i := strings.LastIndex(name, sep)
+	if i < 0 {
+		return "", "", false
+	}
+	return name[:i], name[i+len(sep):], true
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bttest
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FaultPolicy describes a deterministic schedule of failures to inject
+// into a single gRPC method on a Server, so a client's retry and
+// resumption logic can be exercised without depending on real backend
+// flakiness.
+//
+// The schedule is 1-indexed over calls to the method: the AfterNCallsth
+// call fails, and then (if RepeatEvery is nonzero) every RepeatEveryth
+// call after that fails too.
+type FaultPolicy struct {
+	// ErrorCode is returned instead of the handler's real result. Left
+	// at codes.OK (the zero value), the injected failure carries
+	// codes.Unavailable.
+	ErrorCode codes.Code
+
+	// AfterNCalls is the 1-indexed call number that first fails. 0 means
+	// "never", so a zero-value FaultPolicy injects nothing.
+	AfterNCalls int
+
+	// RepeatEvery, if nonzero, also fails every RepeatEveryth call after
+	// AfterNCalls (AfterNCalls, AfterNCalls+RepeatEvery, ...).
+	RepeatEvery int
+
+	// DisconnectAfterChunks, for ReadRows only, ends the stream with
+	// codes.Unavailable once at least this many chunks have been sent
+	// (checked after each whole row) instead of returning ErrorCode up
+	// front, simulating a mid-stream disconnect that the client must resume
+	// with a row key/resumption token.
+	DisconnectAfterChunks int
+
+	// PerRowFailures, for MutateRows only, marks the given 0-indexed
+	// entries as failed with ErrorCode instead of failing the whole RPC.
+	PerRowFailures []int
+}
+
+func (p FaultPolicy) appliesToCall(n int) bool {
+	if p.AfterNCalls <= 0 || n < p.AfterNCalls {
+		return false
+	}
+	if n == p.AfterNCalls {
+		return true
+	}
+	return p.RepeatEvery > 0 && (n-p.AfterNCalls)%p.RepeatEvery == 0
+}
+
+// faultRegistry tracks injected FaultPolicy values and how many times
+// each method has been called, keyed by gRPC method name
+// ("ReadRows", "MutateRow", "MutateRows", "SampleRowKeys", "CheckAndMutateRow").
+type faultRegistry struct {
+	mu       sync.Mutex
+	policies map[string]FaultPolicy
+	calls    map[string]int
+}
+
+func newFaultRegistry() *faultRegistry {
+	return &faultRegistry{policies: make(map[string]FaultPolicy), calls: make(map[string]int)}
+}
+
+// inject records a call to method and reports whether this particular
+// call should fail per its FaultPolicy (if any).
+func (r *faultRegistry) inject(method string) (FaultPolicy, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[method]++
+	p, ok := r.policies[method]
+	if !ok {
+		return FaultPolicy{}, false
+	}
+	return p, p.appliesToCall(r.calls[method])
+}
+
+// InjectFault registers a deterministic FaultPolicy for method, replacing
+// any previous policy for it. Supported method names are "ReadRows",
+// "MutateRow", "MutateRows", "SampleRowKeys", and "CheckAndMutateRow".
+func (s *Server) InjectFault(method string, policy FaultPolicy) {
+	s.s.faults.mu.Lock()
+	defer s.s.faults.mu.Unlock()
+	s.s.faults.policies[method] = policy
+}
+
+// ResetFaults clears every injected FaultPolicy and call counter, for
+// test isolation between subtests that share a Server.
+func (s *Server) ResetFaults() {
+	s.s.faults.mu.Lock()
+	defer s.s.faults.mu.Unlock()
+	s.s.faults.policies = make(map[string]FaultPolicy)
+	s.s.faults.calls = make(map[string]int)
+}
+
+func faultError(p FaultPolicy) error {
+	code := p.ErrorCode
+	if code == codes.OK {
+		code = codes.Unavailable
+	}
+	return status.Errorf(code, "bttest: injected fault")
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bttest contains test helpers for working with the bigtable package.
+//
+// It is unauthenticated, and only a rough approximation of Cloud Bigtable's
+// semantics. It is suitable for unit tests against the bigtable package, and
+// for hermetic integration test runs that would otherwise require a real
+// instance or the external cbtemulator binary.
+package bttest
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server is an in-memory Cloud Bigtable fake.
+// It is unauthenticated, and only a rough approximation.
+type Server struct {
+	Addr string
+
+	l   net.Listener
+	srv *grpc.Server
+	s   *server
+}
+
+// NewServer creates a new Server, listening on the TCP address laddr (a
+// port of 0, as in "localhost:0", lets the OS pick one), and starts
+// serving from it.
+//
+// The Server's Addr field can be used as the laddr for a grpc.Dial from a
+// test, as in:
+//
+//	srv, err := bttest.NewServer("localhost:0")
+//	...
+//	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+func NewServer(laddr string, opt ...grpc.ServerOption) (*Server, error) {
+	l, err := net.Listen("tcp", laddr)
+	if err != nil {
+		return nil, err
+	}
+	return NewServerWithListener(l, opt...)
+}
+
+// NewServerWithListener creates a new Server that serves over an arbitrary
+// net.Listener, such as one produced by
+// google.golang.org/grpc/test/bufconn, so that a test process can talk to
+// the fake entirely in-memory without binding a real socket.
+func NewServerWithListener(l net.Listener, opt ...grpc.ServerOption) (*Server, error) {
+	srv := newServer()
+	opt = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(srv.clusterRoutingUnaryInterceptor),
+		grpc.ChainStreamInterceptor(srv.clusterRoutingStreamInterceptor),
+	}, opt...)
+	s := &Server{
+		Addr: l.Addr().String(),
+		l:    l,
+		srv:  grpc.NewServer(opt...),
+		s:    srv,
+	}
+	btpb.RegisterBigtableServer(s.srv, s.s)
+	btapb.RegisterBigtableTableAdminServer(s.srv, s.s)
+
+	go s.srv.Serve(s.l)
+
+	return s, nil
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	if s.s.persist != nil {
+		s.s.persist.snapshot()
+		s.s.persist.stop()
+	}
+	s.srv.Stop()
+	s.l.Close()
+}
+
+// server is the Bigtable fake itself, implementing both the data API
+// (btpb.BigtableServer) and the subset of the table admin API needed to
+// create and tear down tables in tests.
+type server struct {
+	btpb.UnimplementedBigtableServer
+	btapb.UnimplementedBigtableTableAdminServer
+
+	mu      sync.Mutex
+	tables  map[string]*table // keyed by fully qualified table name; the default cluster's store
+	persist *persister        // non-nil when the Server was built WithPersistence
+	faults  *faultRegistry
+
+	// Multi-cluster routing simulation (see routing.go). clustersMu guards
+	// all of the following; clusters never includes an entry for
+	// defaultClusterID, which is always s.tables above.
+	clustersMu     sync.Mutex
+	clusters       map[string]*cluster
+	profiles       map[string]RoutingPolicy
+	rrCounters     map[string]uint64
+	failoverTarget map[string]string
+	replicationLag time.Duration
+}
+
+func newServer() *server {
+	return &server{
+		tables:         make(map[string]*table),
+		faults:         newFaultRegistry(),
+		clusters:       make(map[string]*cluster),
+		profiles:       make(map[string]RoutingPolicy),
+		rrCounters:     make(map[string]uint64),
+		failoverTarget: make(map[string]string),
+	}
+}
+
+// CreateTable implements btapb.BigtableTableAdminServer.
+func (s *server) CreateTable(ctx context.Context, req *btapb.CreateTableRequest) (*btapb.Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tbl := req.Parent + "/tables/" + req.TableId
+	if _, ok := s.tables[tbl]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "table %q already exists", tbl)
+	}
+	t := newTable(req.GetTable())
+	t.name = tbl
+	t.persist = s.persist
+	s.tables[tbl] = t
+
+	s.clustersMu.Lock()
+	for _, c := range s.clusters {
+		replica := newTable(req.GetTable())
+		replica.name = tbl
+		c.tables[tbl] = replica
+	}
+	s.clustersMu.Unlock()
+
+	return t.proto(tbl), nil
+}
+
+// GetTable implements btapb.BigtableTableAdminServer.
+func (s *server) GetTable(ctx context.Context, req *btapb.GetTableRequest) (*btapb.Table, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Name)
+	}
+	return tbl.proto(req.Name), nil
+}
+
+// ListTables implements btapb.BigtableTableAdminServer.
+func (s *server) ListTables(ctx context.Context, req *btapb.ListTablesRequest) (*btapb.ListTablesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := &btapb.ListTablesResponse{}
+	for name, tbl := range s.tables {
+		if !strings.HasPrefix(name, req.Parent+"/tables/") {
+			continue
+		}
+		res.Tables = append(res.Tables, tbl.proto(name))
+	}
+	return res, nil
+}
+
+// DeleteTable implements btapb.BigtableTableAdminServer.
+func (s *server) DeleteTable(ctx context.Context, req *btapb.DeleteTableRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tables[req.Name]; !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Name)
+	}
+	delete(s.tables, req.Name)
+	return &emptypb.Empty{}, nil
+}
+
+// ModifyColumnFamilies implements btapb.BigtableTableAdminServer.
+func (s *server) ModifyColumnFamilies(ctx context.Context, req *btapb.ModifyColumnFamiliesRequest) (*btapb.Table, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Name)
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	for _, mod := range req.Modifications {
+		switch {
+		case mod.GetCreate() != nil:
+			tbl.families[mod.Id] = mod.GetCreate()
+		case mod.GetUpdate() != nil:
+			tbl.families[mod.Id] = mod.GetUpdate()
+		case mod.GetDrop():
+			delete(tbl.families, mod.Id)
+			for _, r := range tbl.rows {
+				delete(r.cells, mod.Id)
+			}
+		}
+	}
+	return tbl.proto(req.Name), nil
+}
+
+// DropRowRange implements btapb.BigtableTableAdminServer.
+func (s *server) DropRowRange(ctx context.Context, req *btapb.DropRowRangeRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	tbl, ok := s.tables[req.Name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "table %q not found", req.Name)
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	if req.GetDeleteAllDataFromTable() {
+		tbl.rows = make(map[string]*row)
+		return &emptypb.Empty{}, nil
+	}
+	prefix := string(req.GetRowKeyPrefix())
+	for k := range tbl.rows {
+		if strings.HasPrefix(k, prefix) {
+			delete(tbl.rows, k)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// table is the in-memory representation of a single Bigtable table.
+type table struct {
+	mu                 sync.RWMutex
+	name               string
+	families           map[string]*btapb.ColumnFamily
+	rows               map[string]*row
+	deletionProtection bool
+	persist            *persister // non-nil when the owning Server was built WithPersistence
+
+	// authorizedViews and schemaBundles hold the table's child resources,
+	// keyed by their bare ID (see authorized_view.go). They're separate
+	// from families/rows since neither is touched by the data-path RPCs
+	// in data.go.
+	authorizedViews map[string]*btapb.AuthorizedView
+	schemaBundles   map[string]*btapb.SchemaBundle
+}
+
+func newTable(req *btapb.Table) *table {
+	t := &table{
+		families:        make(map[string]*btapb.ColumnFamily),
+		rows:            make(map[string]*row),
+		authorizedViews: make(map[string]*btapb.AuthorizedView),
+		schemaBundles:   make(map[string]*btapb.SchemaBundle),
+	}
+	if req != nil {
+		for id, fam := range req.GetColumnFamilies() {
+			t.families[id] = fam
+		}
+		t.deletionProtection = req.GetDeletionProtection()
+	}
+	return t
+}
+
+func (t *table) proto(name string) *btapb.Table {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	fams := make(map[string]*btapb.ColumnFamily, len(t.families))
+	for id, fam := range t.families {
+		fams[id] = fam
+	}
+	return &btapb.Table{
+		Name:               name,
+		ColumnFamilies:     fams,
+		Granularity:        btapb.Table_MILLIS,
+		DeletionProtection: t.deletionProtection,
+	}
+}
+
+func (t *table) mutableRow(key string) *row {
+	r, ok := t.rows[key]
+	if !ok {
+		r = &row{key: key, cells: make(map[string]map[string][]cell)}
+		t.rows[key] = r
+	}
+	return r
+}
+
+// row is a single Bigtable row: family -> qualifier -> cells, sorted by
+// descending timestamp as they come in.
+type row struct {
+	key   string
+	cells map[string]map[string][]cell
+}
+
+type cell struct {
+	ts    int64
+	value []byte
+}
+
+func (r *row) set(family, qualifier string, ts int64, value []byte) {
+	fam, ok := r.cells[family]
+	if !ok {
+		fam = make(map[string][]cell)
+		r.cells[family] = fam
+	}
+	for i := range fam[qualifier] {
+		if fam[qualifier][i].ts == ts {
+			fam[qualifier][i].value = value
+			return
+		}
+	}
+	cells := append(fam[qualifier], cell{ts: ts, value: value})
+	sort.Slice(cells, func(i, j int) bool { return cells[i].ts > cells[j].ts })
+	fam[qualifier] = cells
+}
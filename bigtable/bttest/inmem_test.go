@@ -0,0 +1,252 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bttest
+
+import (
+	"context"
+	"testing"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeReadRowsStream is a minimal btpb.Bigtable_ReadRowsServer that
+// collects every response sent to it, standing in for a real gRPC stream
+// the way routedServerStream (routing.go) stands in for one at the
+// interceptor layer.
+type fakeReadRowsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*btpb.ReadRowsResponse
+}
+
+func (f *fakeReadRowsStream) Context() context.Context { return f.ctx }
+
+func (f *fakeReadRowsStream) Send(resp *btpb.ReadRowsResponse) error {
+	f.sent = append(f.sent, resp)
+	return nil
+}
+
+// readAllCells drains every chunk across every response f collected into a
+// family -> qualifier -> value map, for tests that only care about the
+// final cell contents rather than how they were chunked.
+func (f *fakeReadRowsStream) readAllCells() map[string]map[string]string {
+	out := make(map[string]map[string]string)
+	for _, resp := range f.sent {
+		for _, c := range resp.Chunks {
+			fam := c.GetFamilyName().GetValue()
+			if _, ok := out[fam]; !ok {
+				out[fam] = make(map[string]string)
+			}
+			out[fam][string(c.GetQualifier().GetValue())] = string(c.Value)
+		}
+	}
+	return out
+}
+
+func mustCreateTable(t *testing.T, s *server, name string) *table {
+	t.Helper()
+	resp, err := s.CreateTable(context.Background(), &btapb.CreateTableRequest{
+		Parent:  "projects/p/instances/i",
+		TableId: name,
+		Table: &btapb.Table{
+			ColumnFamilies: map[string]*btapb.ColumnFamily{"cf": {}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateTable: %v", err)
+	}
+	tbl, ok := s.tables[resp.Name]
+	if !ok {
+		t.Fatalf("CreateTable did not register table %q", resp.Name)
+	}
+	return tbl
+}
+
+func TestServerMutateRowThenReadRows(t *testing.T) {
+	s := newServer()
+	tbl := mustCreateTable(t, s, "t1")
+
+	if _, err := s.MutateRow(context.Background(), &btpb.MutateRowRequest{
+		TableName: tbl.name,
+		RowKey:    []byte("row1"),
+		Mutations: []*btpb.Mutation{{
+			Mutation: &btpb.Mutation_SetCell_{SetCell: &btpb.Mutation_SetCell{
+				FamilyName:      "cf",
+				ColumnQualifier: []byte("col"),
+				TimestampMicros: 1000,
+				Value:           []byte("hello"),
+			}},
+		}},
+	}); err != nil {
+		t.Fatalf("MutateRow: %v", err)
+	}
+
+	stream := &fakeReadRowsStream{ctx: context.Background()}
+	if err := s.ReadRows(&btpb.ReadRowsRequest{TableName: tbl.name}, stream); err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	got := stream.readAllCells()
+	want := "hello"
+	if got["cf"]["col"] != want {
+		t.Errorf("ReadRows got cf/col = %q, want %q", got["cf"]["col"], want)
+	}
+}
+
+func TestServerReadRowsTableNotFound(t *testing.T) {
+	s := newServer()
+	stream := &fakeReadRowsStream{ctx: context.Background()}
+	err := s.ReadRows(&btpb.ReadRowsRequest{TableName: "projects/p/instances/i/tables/missing"}, stream)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("ReadRows on missing table got err %v, want NotFound", err)
+	}
+}
+
+func TestServerCheckAndMutateRow(t *testing.T) {
+	s := newServer()
+	tbl := mustCreateTable(t, s, "t1")
+
+	setCell := func(value string) *btpb.Mutation {
+		return &btpb.Mutation{Mutation: &btpb.Mutation_SetCell_{SetCell: &btpb.Mutation_SetCell{
+			FamilyName:      "cf",
+			ColumnQualifier: []byte("col"),
+			TimestampMicros: 1,
+			Value:           []byte(value),
+		}}}
+	}
+
+	// Predicate filter matches nothing since the row doesn't exist yet, so
+	// FalseMutations should apply.
+	resp, err := s.CheckAndMutateRow(context.Background(), &btpb.CheckAndMutateRowRequest{
+		TableName:       tbl.name,
+		RowKey:          []byte("row1"),
+		PredicateFilter: &btpb.RowFilter{Filter: &btpb.RowFilter_PassAllFilter{PassAllFilter: true}},
+		FalseMutations:  []*btpb.Mutation{setCell("false-branch")},
+		TrueMutations:   []*btpb.Mutation{setCell("true-branch")},
+	})
+	if err != nil {
+		t.Fatalf("CheckAndMutateRow: %v", err)
+	}
+	if resp.PredicateMatched {
+		t.Error("CheckAndMutateRow on empty row reported PredicateMatched = true, want false")
+	}
+	if got := string(tbl.rows["row1"].cells["cf"]["col"][0].value); got != "false-branch" {
+		t.Errorf("row1 cf/col = %q, want %q", got, "false-branch")
+	}
+
+	// Now the row exists, so the same PassAllFilter predicate matches.
+	resp, err = s.CheckAndMutateRow(context.Background(), &btpb.CheckAndMutateRowRequest{
+		TableName:       tbl.name,
+		RowKey:          []byte("row1"),
+		PredicateFilter: &btpb.RowFilter{Filter: &btpb.RowFilter_PassAllFilter{PassAllFilter: true}},
+		FalseMutations:  []*btpb.Mutation{setCell("false-branch")},
+		TrueMutations:   []*btpb.Mutation{setCell("true-branch")},
+	})
+	if err != nil {
+		t.Fatalf("CheckAndMutateRow: %v", err)
+	}
+	if !resp.PredicateMatched {
+		t.Error("CheckAndMutateRow on existing row reported PredicateMatched = false, want true")
+	}
+	if got := string(tbl.rows["row1"].cells["cf"]["col"][0].value); got != "true-branch" {
+		t.Errorf("row1 cf/col = %q, want %q", got, "true-branch")
+	}
+}
+
+func TestServerReadModifyWriteRow(t *testing.T) {
+	s := newServer()
+	tbl := mustCreateTable(t, s, "t1")
+
+	incr := &btpb.ReadModifyWriteRule{
+		FamilyName:      "cf",
+		ColumnQualifier: []byte("counter"),
+		Rule:            &btpb.ReadModifyWriteRule_IncrementAmount{IncrementAmount: 5},
+	}
+	if _, err := s.ReadModifyWriteRow(context.Background(), &btpb.ReadModifyWriteRowRequest{
+		TableName: tbl.name,
+		RowKey:    []byte("row1"),
+		Rules:     []*btpb.ReadModifyWriteRule{incr},
+	}); err != nil {
+		t.Fatalf("ReadModifyWriteRow (first increment): %v", err)
+	}
+	resp, err := s.ReadModifyWriteRow(context.Background(), &btpb.ReadModifyWriteRowRequest{
+		TableName: tbl.name,
+		RowKey:    []byte("row1"),
+		Rules:     []*btpb.ReadModifyWriteRule{incr},
+	})
+	if err != nil {
+		t.Fatalf("ReadModifyWriteRow (second increment): %v", err)
+	}
+	cells := resp.Row.Families[0].Columns[0].Cells
+	if len(cells) != 1 {
+		t.Fatalf("got %d cells, want 1", len(cells))
+	}
+	got := int64(cells[0].Value[7]) // low byte of an 8-byte big-endian counter that never exceeds 255
+	if got != 10 {
+		t.Errorf("counter after two increments of 5 = %d, want 10", got)
+	}
+}
+
+func TestRowInRange(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		key  string
+		rr   *btpb.RowRange
+		want bool
+	}{
+		{
+			desc: "closed start, closed end, inside",
+			key:  "m",
+			rr: &btpb.RowRange{
+				StartKey: &btpb.RowRange_StartKeyClosed{StartKeyClosed: []byte("a")},
+				EndKey:   &btpb.RowRange_EndKeyClosed{EndKeyClosed: []byte("z")},
+			},
+			want: true,
+		},
+		{
+			desc: "open start excludes boundary",
+			key:  "a",
+			rr:   &btpb.RowRange{StartKey: &btpb.RowRange_StartKeyOpen{StartKeyOpen: []byte("a")}},
+			want: false,
+		},
+		{
+			desc: "closed start includes boundary",
+			key:  "a",
+			rr:   &btpb.RowRange{StartKey: &btpb.RowRange_StartKeyClosed{StartKeyClosed: []byte("a")}},
+			want: true,
+		},
+		{
+			desc: "open end excludes boundary",
+			key:  "z",
+			rr:   &btpb.RowRange{EndKey: &btpb.RowRange_EndKeyOpen{EndKeyOpen: []byte("z")}},
+			want: false,
+		},
+		{
+			desc: "empty end key means unbounded",
+			key:  "zzzzzz",
+			rr:   &btpb.RowRange{EndKey: &btpb.RowRange_EndKeyClosed{EndKeyClosed: []byte("")}},
+			want: true,
+		},
+	} {
+		if got := rowInRange(test.key, test.rr); got != test.want {
+			t.Errorf("%s: rowInRange(%q) = %v, want %v", test.desc, test.key, got, test.want)
+		}
+	}
+}
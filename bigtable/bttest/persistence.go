@@ -0,0 +1,324 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bttest
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Option configures a Server created by NewServerWithOptions.
+type Option func(*serverConfig)
+
+type serverConfig struct {
+	grpcOpts         []grpc.ServerOption
+	persistDir       string
+	snapshotInterval time.Duration
+}
+
+// WithPersistence makes a Server snapshot its tables (families, GC
+// policies, and rows) to dir and append a write-ahead log of row
+// mutations between snapshots, so data survives a process restart. dir is
+// created if it doesn't already exist.
+func WithPersistence(dir string) Option {
+	return func(c *serverConfig) { c.persistDir = dir }
+}
+
+// WithSnapshotInterval overrides the default interval (30s) at which a
+// persistent Server compacts its WAL into a fresh snapshot. It has no
+// effect unless WithPersistence is also given.
+func WithSnapshotInterval(d time.Duration) Option {
+	return func(c *serverConfig) { c.snapshotInterval = d }
+}
+
+// WithGRPCServerOptions passes opt through to the underlying grpc.Server,
+// equivalent to the opt... parameter on NewServer.
+func WithGRPCServerOptions(opt ...grpc.ServerOption) Option {
+	return func(c *serverConfig) { c.grpcOpts = append(c.grpcOpts, opt...) }
+}
+
+const defaultSnapshotInterval = 30 * time.Second
+
+// NewServerWithOptions is like NewServer, but accepts Option values such as
+// WithPersistence for on-disk state instead of a plain grpc.ServerOption list.
+func NewServerWithOptions(laddr string, opts ...Option) (*Server, error) {
+	l, err := net.Listen("tcp", laddr)
+	if err != nil {
+		return nil, err
+	}
+	return newServerWithOptionsAndListener(l, opts...)
+}
+
+func newServerWithOptionsAndListener(l net.Listener, opts ...Option) (*Server, error) {
+	cfg := &serverConfig{snapshotInterval: defaultSnapshotInterval}
+	for _, o := range opts {
+		o(cfg)
+	}
+
+	srv := newServer()
+
+	if cfg.persistDir != "" {
+		p, err := newPersister(cfg.persistDir, cfg.snapshotInterval)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		if err := p.replayInto(srv); err != nil {
+			l.Close()
+			return nil, err
+		}
+		p.srv = srv
+		srv.persist = p
+		for _, t := range srv.tables {
+			t.persist = p
+		}
+		p.start()
+	}
+
+	grpcOpts := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(srv.clusterRoutingUnaryInterceptor),
+		grpc.ChainStreamInterceptor(srv.clusterRoutingStreamInterceptor),
+	}, cfg.grpcOpts...)
+	s := &Server{
+		Addr: l.Addr().String(),
+		l:    l,
+		srv:  grpc.NewServer(grpcOpts...),
+		s:    srv,
+	}
+	btpb.RegisterBigtableServer(s.srv, s.s)
+	btapb.RegisterBigtableTableAdminServer(s.srv, s.s)
+	go s.srv.Serve(s.l)
+	return s, nil
+}
+
+// persister snapshots server state to snapshotPath and appends row
+// mutations to walPath between snapshots. A single mutex serializes
+// access; this is a test fake, not a high-throughput log.
+type persister struct {
+	mu               sync.Mutex
+	dir              string
+	snapshotInterval time.Duration
+	wal              *os.File
+	stopCh           chan struct{}
+	srv              *server
+}
+
+func newPersister(dir string, interval time.Duration) (*persister, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("bttest: creating persistence dir %q: %w", dir, err)
+	}
+	wal, err := os.OpenFile(filepath.Join(dir, "wal.gob"), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("bttest: opening WAL: %w", err)
+	}
+	return &persister{dir: dir, snapshotInterval: interval, wal: wal, stopCh: make(chan struct{})}, nil
+}
+
+func (p *persister) snapshotPath() string { return filepath.Join(p.dir, "snapshot.gob") }
+
+// diskSnapshot is the gob-encoded unit written by snapshot and read by
+// replayInto. Column family configuration is kept as raw marshaled proto
+// bytes so this file doesn't need to track every btapb.ColumnFamily field
+// that gob would otherwise choke on (unexported state, oneofs, etc.).
+type diskSnapshot struct {
+	Tables map[string]diskTable
+}
+
+type diskTable struct {
+	FamiliesProto      map[string][]byte
+	DeletionProtection bool
+	Rows               []diskRow
+}
+
+type diskRow struct {
+	Key   string
+	Cells []diskCell
+}
+
+type diskCell struct {
+	Family    string
+	Qualifier string
+	Timestamp int64
+	Value     []byte
+}
+
+// diskMutation is one WAL record: a MutateRow-equivalent applied to table.
+type diskMutation struct {
+	Table       string
+	Key         string
+	MutationsPb [][]byte
+}
+
+// replayInto loads the most recent snapshot (if any) and replays the WAL
+// written since, mutating srv in place. It's called once, before the
+// server starts accepting RPCs.
+func (p *persister) replayInto(srv *server) error {
+	if data, err := os.ReadFile(p.snapshotPath()); err == nil {
+		var snap diskSnapshot
+		dec := gob.NewDecoder(bytes.NewReader(data))
+		if err := dec.Decode(&snap); err != nil {
+			return fmt.Errorf("bttest: decoding snapshot: %w", err)
+		}
+		for name, dt := range snap.Tables {
+			t := &table{
+				name:               name,
+				families:           make(map[string]*btapb.ColumnFamily),
+				rows:               make(map[string]*row),
+				deletionProtection: dt.DeletionProtection,
+			}
+			for id, b := range dt.FamiliesProto {
+				fam := &btapb.ColumnFamily{}
+				if err := proto.Unmarshal(b, fam); err != nil {
+					return fmt.Errorf("bttest: decoding family %q: %w", id, err)
+				}
+				t.families[id] = fam
+			}
+			for _, dr := range dt.Rows {
+				r := t.mutableRow(dr.Key)
+				for _, c := range dr.Cells {
+					r.set(c.Family, c.Qualifier, c.Timestamp, c.Value)
+				}
+			}
+			srv.tables[name] = t
+		}
+	}
+
+	if _, err := p.wal.Seek(0, 0); err != nil {
+		return err
+	}
+	dec := gob.NewDecoder(p.wal)
+	for {
+		var m diskMutation
+		if err := dec.Decode(&m); err != nil {
+			break // EOF, or a partial trailing record from a crash mid-append
+		}
+		tbl, ok := srv.tables[m.Table]
+		if !ok {
+			continue
+		}
+		var muts []*btpb.Mutation
+		for _, b := range m.MutationsPb {
+			mu := &btpb.Mutation{}
+			if err := proto.Unmarshal(b, mu); err != nil {
+				continue
+			}
+			muts = append(muts, mu)
+		}
+		applyMutations(tbl, m.Key, muts)
+	}
+	if _, err := p.wal.Seek(0, 2); err != nil {
+		return err
+	}
+	return nil
+}
+
+// logMutation appends a WAL record for a committed row mutation. Called
+// with the owning table's lock already held by the caller.
+func (p *persister) logMutation(tableName, key string, muts []*btpb.Mutation) {
+	if p == nil {
+		return
+	}
+	m := diskMutation{Table: tableName, Key: key}
+	for _, mu := range muts {
+		b, err := proto.Marshal(mu)
+		if err != nil {
+			continue
+		}
+		m.MutationsPb = append(m.MutationsPb, b)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	gob.NewEncoder(p.wal).Encode(m)
+	p.wal.Sync()
+}
+
+func (p *persister) start() {
+	go func() {
+		t := time.NewTicker(p.snapshotInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				p.snapshot()
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (p *persister) stop() { close(p.stopCh) }
+
+// snapshot writes the server's current state to snapshotPath and
+// truncates the WAL, since everything in it is now reflected in the
+// snapshot. Called by the background ticker and once more from Close.
+func (p *persister) snapshot() {
+	srv := p.srv
+	srv.mu.Lock()
+	snap := diskSnapshot{Tables: make(map[string]diskTable, len(srv.tables))}
+	for name, t := range srv.tables {
+		t.mu.RLock()
+		dt := diskTable{FamiliesProto: make(map[string][]byte, len(t.families)), DeletionProtection: t.deletionProtection}
+		for id, fam := range t.families {
+			if b, err := proto.Marshal(fam); err == nil {
+				dt.FamiliesProto[id] = b
+			}
+		}
+		for key, r := range t.rows {
+			dr := diskRow{Key: key}
+			for fam, quals := range r.cells {
+				for qual, cells := range quals {
+					for _, c := range cells {
+						dr.Cells = append(dr.Cells, diskCell{Family: fam, Qualifier: qual, Timestamp: c.ts, Value: c.value})
+					}
+				}
+			}
+			dt.Rows = append(dt.Rows, dr)
+		}
+		t.mu.RUnlock()
+		snap.Tables[name] = dt
+	}
+	srv.mu.Unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	tmp := p.snapshotPath() + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return
+	}
+	if err := gob.NewEncoder(f).Encode(snap); err != nil {
+		f.Close()
+		return
+	}
+	f.Close()
+	os.Rename(tmp, p.snapshotPath())
+
+	p.wal.Truncate(0)
+	p.wal.Seek(0, 0)
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bttest
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// defaultClusterID names the cluster a Server starts with before any call
+// to AddCluster, and the one every table's rows live in until then.
+const defaultClusterID = "default"
+
+type routingKind int
+
+const (
+	singleClusterRouting routingKind = iota
+	roundRobinRouting
+	latencyWeightedRouting
+	failoverRouting
+)
+
+// RoutingPolicy describes how a Server picks which cluster an RPC under a
+// given app profile lands on, mirroring the routing choices a real app
+// profile offers: pin to one cluster, spread across several, or fail over
+// from a primary to a secondary.
+type RoutingPolicy struct {
+	kind     routingKind
+	clusters []string
+	delays   map[string]time.Duration
+}
+
+// SingleCluster pins every call under the profile to clusterID.
+func SingleCluster(clusterID string) RoutingPolicy {
+	return RoutingPolicy{kind: singleClusterRouting, clusters: []string{clusterID}}
+}
+
+// MultiClusterRoundRobin spreads calls under the profile evenly across
+// clusterIDs, one at a time in order.
+func MultiClusterRoundRobin(clusterIDs ...string) RoutingPolicy {
+	return RoutingPolicy{kind: roundRobinRouting, clusters: append([]string(nil), clusterIDs...)}
+}
+
+// MultiClusterLatencyWeighted routes each call to whichever cluster has the
+// lowest configured delay in delays, sleeping for that delay first to
+// simulate the cost of reaching it.
+func MultiClusterLatencyWeighted(delays map[string]time.Duration) RoutingPolicy {
+	clusters := make([]string, 0, len(delays))
+	for id := range delays {
+		clusters = append(clusters, id)
+	}
+	sort.Strings(clusters)
+	return RoutingPolicy{kind: latencyWeightedRouting, clusters: clusters, delays: delays}
+}
+
+// Failover routes every call to primary until FailoverAppProfile names one
+// of secondaries as the new target.
+func Failover(primary string, secondaries ...string) RoutingPolicy {
+	return RoutingPolicy{kind: failoverRouting, clusters: append([]string{primary}, secondaries...)}
+}
+
+// replicated reports whether writes made under this policy should be
+// asynchronously replicated to the policy's other clusters. A
+// single-cluster profile has nowhere else to replicate to.
+func (p RoutingPolicy) replicated() bool {
+	return p.kind != singleClusterRouting && len(p.clusters) > 1
+}
+
+// cluster is a virtual Bigtable cluster inside a Server: an independent
+// replica of every table's rows, reachable by RoutingPolicy and kept in
+// sync with its peers only by explicit, laggy replication.
+type cluster struct {
+	id string
+
+	mu     sync.RWMutex
+	tables map[string]*table // fully qualified table name -> this cluster's replica
+}
+
+func newCluster(id string) *cluster {
+	return &cluster{id: id, tables: make(map[string]*table)}
+}
+
+// AddCluster registers a new virtual cluster, seeded with a replica of
+// every existing table's column family schema (but none of its rows), so
+// a RoutingPolicy naming it has somewhere to land writes. It's a no-op if
+// id is already registered.
+func (s *Server) AddCluster(id string) {
+	srv := s.s
+	srv.clustersMu.Lock()
+	defer srv.clustersMu.Unlock()
+	if _, ok := srv.clusters[id]; ok {
+		return
+	}
+	c := newCluster(id)
+
+	srv.mu.Lock()
+	for name, tbl := range srv.tables {
+		tbl.mu.RLock()
+		replica := newTable(nil)
+		replica.name = name
+		replica.deletionProtection = tbl.deletionProtection
+		for famID, fam := range tbl.families {
+			replica.families[famID] = fam
+		}
+		tbl.mu.RUnlock()
+		c.tables[name] = replica
+	}
+	srv.mu.Unlock()
+
+	srv.clusters[id] = c
+}
+
+// AddAppProfile registers policy under id, so an RPC whose
+// x-goog-request-params metadata carries app_profile_id=id is routed
+// according to policy instead of the default cluster.
+func (s *Server) AddAppProfile(id string, policy RoutingPolicy) {
+	srv := s.s
+	srv.clustersMu.Lock()
+	defer srv.clustersMu.Unlock()
+	srv.profiles[id] = policy
+}
+
+// SetReplicationLag configures how long AddAppProfile's multi-cluster and
+// failover policies wait before a write becomes visible on a peer cluster
+// it wasn't applied to directly. It defaults to zero (instant replication).
+func (s *Server) SetReplicationLag(d time.Duration) {
+	srv := s.s
+	srv.clustersMu.Lock()
+	defer srv.clustersMu.Unlock()
+	srv.replicationLag = d
+}
+
+// FailoverAppProfile redirects subsequent calls under a Failover app
+// profile away from its primary and onto clusterID, one of the secondaries
+// originally passed to Failover. Passing the original primary fails back.
+func (s *Server) FailoverAppProfile(profileID, clusterID string) {
+	srv := s.s
+	srv.clustersMu.Lock()
+	defer srv.clustersMu.Unlock()
+	srv.failoverTarget[profileID] = clusterID
+}
+
+// routingDecision is stashed on the context of every incoming RPC by the
+// cluster-routing interceptors, recording which cluster the call landed on
+// and, for a replicated policy, which peers should eventually see the
+// write too.
+type routingDecision struct {
+	clusterID string
+	peers     []string
+	lag       time.Duration
+}
+
+type routingCtxKey struct{}
+
+func routingFromContext(ctx context.Context) (routingDecision, bool) {
+	d, ok := ctx.Value(routingCtxKey{}).(routingDecision)
+	return d, ok
+}
+
+// route resolves the app_profile_id carried on ctx's incoming metadata (if
+// any) against the registered profiles, and returns the routingDecision to
+// attach to the call's context. RPCs with no app profile, or one with no
+// registered policy, fall through to the default cluster untouched.
+func (s *server) route(ctx context.Context) routingDecision {
+	id, ok := appProfileID(ctx)
+	if !ok {
+		return routingDecision{clusterID: defaultClusterID}
+	}
+
+	s.clustersMu.Lock()
+	policy, ok := s.profiles[id]
+	lag := s.replicationLag
+	failoverTo := s.failoverTarget[id]
+	s.clustersMu.Unlock()
+	if !ok {
+		return routingDecision{clusterID: defaultClusterID}
+	}
+
+	var target string
+	switch policy.kind {
+	case singleClusterRouting:
+		target = policy.clusters[0]
+	case roundRobinRouting:
+		s.clustersMu.Lock()
+		n := s.rrCounters[id]
+		s.rrCounters[id] = n + 1
+		s.clustersMu.Unlock()
+		target = policy.clusters[n%uint64(len(policy.clusters))]
+	case latencyWeightedRouting:
+		target = policy.clusters[0]
+		best := policy.delays[target]
+		for _, c := range policy.clusters[1:] {
+			if d := policy.delays[c]; d < best {
+				target, best = c, d
+			}
+		}
+		time.Sleep(best)
+	case failoverRouting:
+		target = policy.clusters[0]
+		if failoverTo != "" {
+			target = failoverTo
+		}
+	}
+
+	var peers []string
+	if policy.replicated() {
+		for _, c := range policy.clusters {
+			if c != target {
+				peers = append(peers, c)
+			}
+		}
+	}
+	return routingDecision{clusterID: target, peers: peers, lag: lag}
+}
+
+// appProfileID extracts app_profile_id from the x-goog-request-params
+// metadata header, the same header the real client attaches it to.
+func appProfileID(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, v := range md.Get("x-goog-request-params") {
+		q, err := url.ParseQuery(v)
+		if err != nil {
+			continue
+		}
+		if id := q.Get("app_profile_id"); id != "" {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// clusterRoutingUnaryInterceptor attaches a routingDecision to every unary
+// RPC's context before it reaches the handler.
+func (s *server) clusterRoutingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = context.WithValue(ctx, routingCtxKey{}, s.route(ctx))
+	return handler(ctx, req)
+}
+
+// clusterRoutingStreamInterceptor is the streaming-RPC equivalent of
+// clusterRoutingUnaryInterceptor.
+func (s *server) clusterRoutingStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := context.WithValue(ss.Context(), routingCtxKey{}, s.route(ss.Context()))
+	return handler(srv, &routedServerStream{ServerStream: ss, ctx: ctx})
+}
+
+type routedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *routedServerStream) Context() context.Context { return w.ctx }
+
+// targetTable resolves the *table a request for name should operate
+// against: the cluster ctx was routed to, if any cluster but the default
+// was added via AddCluster, or the server's default store otherwise.
+func (s *server) targetTable(ctx context.Context, name string) (*table, bool) {
+	if d, ok := routingFromContext(ctx); ok && d.clusterID != defaultClusterID {
+		s.clustersMu.Lock()
+		c, ok := s.clusters[d.clusterID]
+		s.clustersMu.Unlock()
+		if ok {
+			c.mu.RLock()
+			tbl, ok := c.tables[name]
+			c.mu.RUnlock()
+			return tbl, ok
+		}
+	}
+	s.mu.Lock()
+	tbl, ok := s.tables[name]
+	s.mu.Unlock()
+	return tbl, ok
+}
+
+// replicateAsync applies muts to every peer cluster named in the context's
+// routingDecision, each after the configured replication lag, so a test
+// can observe one cluster read its own write while a peer still sees
+// stale data until the lag elapses.
+func (s *server) replicateAsync(ctx context.Context, tableName, key string, muts []*btpb.Mutation) {
+	d, ok := routingFromContext(ctx)
+	if !ok || len(d.peers) == 0 {
+		return
+	}
+	for _, peerID := range d.peers {
+		peerID := peerID
+		go func() {
+			if d.lag > 0 {
+				time.Sleep(d.lag)
+			}
+			s.clustersMu.Lock()
+			c, ok := s.clusters[peerID]
+			s.clustersMu.Unlock()
+			if !ok {
+				return
+			}
+			c.mu.Lock()
+			tbl, ok := c.tables[tableName]
+			c.mu.Unlock()
+			if !ok {
+				return
+			}
+			tbl.mu.Lock()
+			applyMutations(tbl, key, muts)
+			tbl.mu.Unlock()
+		}()
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BackupRequest identifies one backup to create, as an item in a
+// BulkCreateBackups call.
+type BackupRequest struct {
+	Table   string
+	Cluster string
+	Backup  string
+	Options []BackupOption
+}
+
+// BackupRef identifies one existing backup, in ac's own project and
+// instance, as an item in a BulkDeleteBackups or
+// BulkUpdateBackupExpireTime call.
+type BackupRef struct {
+	Cluster string
+	Backup  string
+}
+
+// BulkBackupProgress reports the outcome of one item in a bulk backup
+// operation, sent to BulkOptions.Progress as each item finishes.
+type BulkBackupProgress struct {
+	Cluster string
+	Backup  string
+	Err     error
+}
+
+// BulkBackupFailure pairs a failed bulk backup item with its error.
+type BulkBackupFailure struct {
+	Cluster string
+	Backup  string
+	Err     error
+}
+
+// BulkOptions configures BulkCreateBackups, BulkDeleteBackups, and
+// BulkUpdateBackupExpireTime's worker pool.
+type BulkOptions struct {
+	// Parallelism caps how many backup RPCs run at once. Zero or
+	// negative means 1 (sequential).
+	Parallelism int
+	// ContinueOnError, if true, keeps processing the remaining items
+	// after one fails instead of returning as soon as one does. Either
+	// way every failure is collected in BulkBackupResult.Failures.
+	ContinueOnError bool
+	// Progress, if non-nil, receives one BulkBackupProgress per item as
+	// it completes. The call blocks sending to it, so the caller must
+	// keep draining it concurrently, in a goroutine started before the
+	// call, for the call's duration.
+	Progress chan<- BulkBackupProgress
+}
+
+// BulkBackupResult is the outcome of a bulk backup operation.
+type BulkBackupResult struct {
+	// Succeeded lists the backups that completed successfully. For
+	// BulkDeleteBackups and BulkUpdateBackupExpireTime, whose items don't
+	// produce a BackupInfo, this is always empty; check Failures instead.
+	Succeeded []*BackupInfo
+	// Failures lists the items that errored, alongside the error.
+	Failures []BulkBackupFailure
+}
+
+// Errs joins every failure in r into a single error, or returns nil if r
+// has none.
+func (r *BulkBackupResult) Errs() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failures))
+	for i, f := range r.Failures {
+		errs[i] = fmt.Errorf("cluster %q backup %q: %w", f.Cluster, f.Backup, f.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// BulkCreateBackups creates every backup in reqs, running up to
+// opts.Parallelism at a time. Unless opts.ContinueOnError, it stops
+// starting new items as soon as one fails and cancels the context of
+// items already in flight, then waits for them to return.
+func (ac *AdminClient) BulkCreateBackups(ctx context.Context, reqs []BackupRequest, opts BulkOptions) (*BulkBackupResult, error) {
+	return runBulkBackupOp(ctx, opts, len(reqs), func(i int) (string, string, func(context.Context) (*BackupInfo, error)) {
+		r := reqs[i]
+		return r.Cluster, r.Backup, func(ctx context.Context) (*BackupInfo, error) {
+			op, err := ac.CreateBackupOperation(ctx, r.Table, r.Cluster, r.Backup, r.Options...)
+			if err != nil {
+				return nil, err
+			}
+			return op.Wait(ctx)
+		}
+	})
+}
+
+// BulkDeleteBackups deletes every backup in backups, running up to
+// opts.Parallelism at a time.
+func (ac *AdminClient) BulkDeleteBackups(ctx context.Context, backups []BackupRef, opts BulkOptions) (*BulkBackupResult, error) {
+	return runBulkBackupOp(ctx, opts, len(backups), func(i int) (string, string, func(context.Context) (*BackupInfo, error)) {
+		b := backups[i]
+		return b.Cluster, b.Backup, func(ctx context.Context) (*BackupInfo, error) {
+			return nil, ac.DeleteBackup(ctx, b.Cluster, b.Backup)
+		}
+	})
+}
+
+// BulkUpdateBackupExpireTime sets the expire time of every backup in
+// backups to expireTime, running up to opts.Parallelism at a time.
+func (ac *AdminClient) BulkUpdateBackupExpireTime(ctx context.Context, backups []BackupRef, expireTime time.Time, opts BulkOptions) (*BulkBackupResult, error) {
+	return runBulkBackupOp(ctx, opts, len(backups), func(i int) (string, string, func(context.Context) (*BackupInfo, error)) {
+		b := backups[i]
+		return b.Cluster, b.Backup, func(ctx context.Context) (*BackupInfo, error) {
+			return nil, ac.UpdateBackup(ctx, b.Cluster, b.Backup, expireTime)
+		}
+	})
+}
+
+// runBulkBackupOp is the shared concurrency-limited worker pool behind
+// BulkCreateBackups, BulkDeleteBackups, and BulkUpdateBackupExpireTime:
+// item(i) returns the (cluster, backup) pair used for progress/failure
+// reporting and a thunk that performs the RPC for item i.
+func runBulkBackupOp(ctx context.Context, opts BulkOptions, n int, item func(i int) (cluster, backup string, run func(context.Context) (*BackupInfo, error))) (*BulkBackupResult, error) {
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	result := &BulkBackupResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stopped bool
+	for i := 0; i < n; i++ {
+		mu.Lock()
+		if stopped {
+			mu.Unlock()
+			break
+		}
+		mu.Unlock()
+
+		cluster, backup, run := item(i)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			info, err := run(runCtx)
+
+			mu.Lock()
+			if err != nil {
+				result.Failures = append(result.Failures, BulkBackupFailure{Cluster: cluster, Backup: backup, Err: err})
+				if !opts.ContinueOnError {
+					stopped = true
+					cancel()
+				}
+			} else if info != nil {
+				result.Succeeded = append(result.Succeeded, info)
+			}
+			mu.Unlock()
+
+			if opts.Progress != nil {
+				opts.Progress <- BulkBackupProgress{Cluster: cluster, Backup: backup, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result, result.Errs()
+}
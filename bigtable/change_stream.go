@@ -0,0 +1,479 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StreamPartition identifies one slice of a table's change stream, keyed
+// by the row range it covers. A table's full change stream is the union
+// of every StreamPartition returned by InitialPartitions; as the table's
+// tablets split or merge, ReadChangeStream ends with a CloseStream record
+// carrying the ContinuationTokens to resume on the replacement partitions.
+type StreamPartition struct {
+	RowRange RowRange
+}
+
+func (p StreamPartition) proto() *btpb.StreamPartition {
+	return &btpb.StreamPartition{RowRange: p.RowRange.proto()}
+}
+
+func streamPartitionFromProto(p *btpb.StreamPartition) StreamPartition {
+	return StreamPartition{RowRange: newRowRangeFromProto(p.GetRowRange())}
+}
+
+// ContinuationToken resumes a ReadChangeStream call on the same
+// StreamPartition (or, after a split/merge, on one of its replacements)
+// from where a previous call left off.
+type ContinuationToken struct {
+	Partition StreamPartition
+	Token     string
+}
+
+func (c ContinuationToken) proto() *btpb.StreamContinuationToken {
+	return &btpb.StreamContinuationToken{Partition: c.Partition.proto(), Token: c.Token}
+}
+
+func continuationTokenFromProto(p *btpb.StreamContinuationToken) ContinuationToken {
+	return ContinuationToken{Partition: streamPartitionFromProto(p.GetPartition()), Token: p.GetToken()}
+}
+
+// DataChangeType identifies why a DataChange record was emitted.
+type DataChangeType int
+
+const (
+	// DataChangeUser is a mutation an application made directly.
+	DataChangeUser DataChangeType = iota
+	// DataChangeGarbageCollection is a mutation Bigtable made to enforce a
+	// column family's garbage collection policy.
+	DataChangeGarbageCollection
+	// DataChangeContinuation marks a DataChange too large for one record;
+	// it carries the next chunk of the DataChange record before it on the
+	// same partition, and only the last one has Done set.
+	DataChangeContinuation
+)
+
+// DataChange is a single logical mutation on the change stream: either a
+// full mutation, or (if split across records) one chunk of one; the
+// chunks of a split mutation arrive as consecutive records on the same
+// partition, ending with the one that has Done set.
+type DataChange struct {
+	Type            DataChangeType
+	RowKey          []byte
+	Mutations       []*btpb.Mutation
+	SourceClusterID string
+	CommitTimestamp time.Time
+	Tiebreaker      int32
+	TransactionID   string
+	Token           string
+	// Done is true once Mutations holds every mutation in this logical
+	// change; false on every record but the last of a continued change.
+	Done bool
+}
+
+// ChangeStreamHeartbeat reports how far the stream has progressed with no
+// new data, so a consumer waiting on a quiet partition can still make
+// resumption progress.
+type ChangeStreamHeartbeat struct {
+	LowWatermark      time.Time
+	ContinuationToken ContinuationToken
+}
+
+// ChangeStreamClose ends a ReadChangeStream call, either because of an
+// error or because the partition split or merged. ContinuationTokens
+// names the replacement partition(s) to resume reading from; an empty
+// Err and a non-empty ContinuationTokens means the original partition's
+// tablet boundaries changed, not that anything went wrong.
+type ChangeStreamClose struct {
+	Err                error
+	ContinuationTokens []ContinuationToken
+}
+
+// ChangeStreamRecord is the typed union ReadChangeStream delivers to its
+// callback. Exactly one field is set.
+type ChangeStreamRecord struct {
+	DataChange  *DataChange
+	Heartbeat   *ChangeStreamHeartbeat
+	CloseStream *ChangeStreamClose
+}
+
+// ReadChangeStreamOption is an option for ChangeStreamReader.ReadChangeStream.
+type ReadChangeStreamOption interface {
+	set(*btpb.ReadChangeStreamRequest)
+}
+
+type readChangeStreamOptionFunc func(*btpb.ReadChangeStreamRequest)
+
+func (f readChangeStreamOptionFunc) set(req *btpb.ReadChangeStreamRequest) { f(req) }
+
+// EndTime stops the stream once a record's commit timestamp reaches t,
+// with a final CloseStream record and no error.
+func EndTime(t time.Time) ReadChangeStreamOption {
+	return readChangeStreamOptionFunc(func(req *btpb.ReadChangeStreamRequest) {
+		req.EndTime = timestamppb.New(t)
+	})
+}
+
+// HeartbeatDuration overrides how often ReadChangeStream delivers a
+// ChangeStreamHeartbeat record on a partition with no new data.
+func HeartbeatDuration(d time.Duration) ReadChangeStreamOption {
+	return readChangeStreamOptionFunc(func(req *btpb.ReadChangeStreamRequest) {
+		req.HeartbeatDuration = durationpb.New(d)
+	})
+}
+
+// ChangeStreamReader reads the change stream of a table with change
+// stream retention enabled (see TableConf.ChangeStreamRetention).
+type ChangeStreamReader struct {
+	t *Table
+}
+
+// NewChangeStreamReader returns a ChangeStreamReader over t's change
+// stream. t must have change stream retention enabled.
+func (t *Table) NewChangeStreamReader() *ChangeStreamReader {
+	return &ChangeStreamReader{t: t}
+}
+
+// InitialPartitions returns the set of StreamPartitions covering the
+// table's entire key space, for starting a fresh read of the change
+// stream with no prior ContinuationTokens.
+func (r *ChangeStreamReader) InitialPartitions(ctx context.Context) ([]StreamPartition, error) {
+	req := &btpb.GenerateInitialChangeStreamPartitionsRequest{
+		TableName:    r.t.c.fullTableName(r.t.table),
+		AppProfileId: r.t.c.appProfile,
+	}
+	stream, err := r.t.c.client.GenerateInitialChangeStreamPartitions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var partitions []StreamPartition
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, streamPartitionFromProto(res.GetPartition()))
+	}
+	return partitions, nil
+}
+
+// ReadChangeStream streams change stream records for a single partition,
+// starting from startTime (if continuationTokens is empty) or from
+// continuationTokens (resuming a prior call; startTime is ignored). f is
+// invoked once per record; returning false stops the stream. A
+// partition's stream ends with exactly one ChangeStreamClose record,
+// whether the end is an error, EndTime being reached, or the partition's
+// tablet boundaries changing underneath it — callers wanting full table
+// coverage across splits and merges should use RunChangeStream instead of
+// calling this directly.
+func (r *ChangeStreamReader) ReadChangeStream(ctx context.Context, partition StreamPartition, startTime time.Time, continuationTokens []ContinuationToken, f func(ChangeStreamRecord) bool, opts ...ReadChangeStreamOption) error {
+	req := &btpb.ReadChangeStreamRequest{
+		TableName:    r.t.c.fullTableName(r.t.table),
+		AppProfileId: r.t.c.appProfile,
+		Partition:    partition.proto(),
+	}
+	if len(continuationTokens) > 0 {
+		for _, ct := range continuationTokens {
+			req.StreamContinuationTokens = append(req.StreamContinuationTokens, ct.proto())
+		}
+	} else if !startTime.IsZero() {
+		req.StartFrom = &btpb.ReadChangeStreamRequest_StartTime{StartTime: timestamppb.New(startTime)}
+	}
+	for _, opt := range opts {
+		opt.set(req)
+	}
+
+	stream, err := r.t.c.client.ReadChangeStream(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rec, ok := changeStreamRecordFromProto(res)
+		if !ok {
+			continue
+		}
+		if !f(rec) {
+			return nil
+		}
+	}
+}
+
+func changeStreamRecordFromProto(res *btpb.ReadChangeStreamResponse) (ChangeStreamRecord, bool) {
+	switch v := res.GetStreamRecord().(type) {
+	case *btpb.ReadChangeStreamResponse_DataChange_:
+		dc := v.DataChange
+		return ChangeStreamRecord{DataChange: &DataChange{
+			Type:            DataChangeType(dc.GetType()),
+			RowKey:          dc.GetRowKey(),
+			Mutations:       dc.GetMutations(),
+			SourceClusterID: dc.GetSourceClusterId(),
+			CommitTimestamp: dc.GetCommitTimestamp().AsTime(),
+			Tiebreaker:      dc.GetTiebreaker(),
+			TransactionID:   dc.GetToken(),
+			Token:           dc.GetToken(),
+			Done:           dc.GetDone(),
+		}}, true
+	case *btpb.ReadChangeStreamResponse_Heartbeat_:
+		hb := v.Heartbeat
+		return ChangeStreamRecord{Heartbeat: &ChangeStreamHeartbeat{
+			LowWatermark:      hb.GetLowWatermark().AsTime(),
+			ContinuationToken: continuationTokenFromProto(hb.GetContinuationToken()),
+		}}, true
+	case *btpb.ReadChangeStreamResponse_CloseStream_:
+		cs := v.CloseStream
+		rec := ChangeStreamRecord{CloseStream: &ChangeStreamClose{}}
+		if st := cs.GetStatus(); st != nil && st.GetCode() != int32(codes.OK) {
+			rec.CloseStream.Err = status.Errorf(codes.Code(st.GetCode()), "%s", st.GetMessage())
+		}
+		for _, ct := range cs.GetContinuationTokens() {
+			rec.CloseStream.ContinuationTokens = append(rec.CloseStream.ContinuationTokens, continuationTokenFromProto(ct))
+		}
+		return rec, true
+	default:
+		return ChangeStreamRecord{}, false
+	}
+}
+
+// RunChangeStream reads the full table's change stream to completion,
+// starting from InitialPartitions, and keeps it that way across the
+// table's lifetime: whenever a partition's ReadChangeStream call ends in
+// a CloseStream record carrying ContinuationTokens (a split or merge),
+// RunChangeStream spawns a replacement reader per token instead of
+// dropping the partition. f is invoked concurrently from one goroutine
+// per live partition; return false from any call to stop every partition
+// and return. opts apply to every partition's ReadChangeStream call.
+func (r *ChangeStreamReader) RunChangeStream(ctx context.Context, startTime time.Time, f func(StreamPartition, ChangeStreamRecord) bool, opts ...ReadChangeStreamOption) error {
+	partitions, err := r.InitialPartitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	var spawn func(partition StreamPartition, tokens []ContinuationToken)
+	spawn = func(partition StreamPartition, tokens []ContinuationToken) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var closeErr error
+			err := r.ReadChangeStream(ctx, partition, startTime, tokens, func(rec ChangeStreamRecord) bool {
+				if rec.CloseStream != nil {
+					closeErr = rec.CloseStream.Err
+					if closeErr == nil {
+						for _, ct := range rec.CloseStream.ContinuationTokens {
+							spawn(ct.Partition, []ContinuationToken{ct})
+						}
+					}
+					return false
+				}
+				if !f(partition, rec) {
+					cancel()
+					return false
+				}
+				return true
+			}, opts...)
+			if err == nil {
+				err = closeErr
+			}
+			if err != nil && ctx.Err() == nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, p := range partitions {
+		spawn(p, nil)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// TokenStore persists a table's change stream ContinuationTokens between
+// runs of RunChangeStreamChan, so a consumer can resume from where it
+// left off instead of re-reading from startTime after a restart.
+type TokenStore interface {
+	// Load returns the ContinuationTokens previously saved for tableName,
+	// or a nil slice if none have been saved yet.
+	Load(ctx context.Context, tableName string) ([]ContinuationToken, error)
+	// Save persists tokens as the latest resume point for tableName,
+	// replacing whatever was previously saved.
+	Save(ctx context.Context, tableName string, tokens []ContinuationToken) error
+}
+
+// ChangeStreamEvent is a ChangeStreamRecord from one partition of a
+// RunChangeStreamChan call, along with any error that ended the stream.
+type ChangeStreamEvent struct {
+	Partition StreamPartition
+	Record    ChangeStreamRecord
+	Err       error
+}
+
+// RunChangeStreamChan is like RunChangeStream, but delivers records over a
+// channel instead of a callback, and checkpoints its progress in
+// tokenStore after every record so a later call can resume instead of
+// starting over from startTime. If tokenStore already has tokens saved
+// for this table, they're used in place of startTime and every
+// partition is resumed from its saved token instead of InitialPartitions.
+//
+// The returned channel is closed once every partition's stream ends; the
+// returned stop function cancels every partition early and must be
+// called once the caller is done draining the channel, to release the
+// goroutines backing it.
+func (r *ChangeStreamReader) RunChangeStreamChan(ctx context.Context, startTime time.Time, tokenStore TokenStore, opts ...ReadChangeStreamOption) (<-chan ChangeStreamEvent, func(), error) {
+	tableName := r.t.c.fullTableName(r.t.table)
+
+	tokens, err := tokenStore.Load(ctx, tableName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bigtable: loading saved continuation tokens: %w", err)
+	}
+
+	var starts []ContinuationToken
+	var partitions []StreamPartition
+	if len(tokens) > 0 {
+		starts = tokens
+		for _, t := range tokens {
+			partitions = append(partitions, t.Partition)
+		}
+	} else {
+		partitions, err = r.InitialPartitions(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan ChangeStreamEvent)
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	save := func(tok ContinuationToken) error {
+		mu.Lock()
+		defer mu.Unlock()
+		replaced := false
+		for i, t := range tokens {
+			if t.Partition.RowRange == tok.Partition.RowRange {
+				tokens[i] = tok
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			tokens = append(tokens, tok)
+		}
+		return tokenStore.Save(ctx, tableName, tokens)
+	}
+
+	var spawn func(partition StreamPartition, continuationTokens []ContinuationToken)
+	spawn = func(partition StreamPartition, continuationTokens []ContinuationToken) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var closeErr error
+			err := r.ReadChangeStream(ctx, partition, startTime, continuationTokens, func(rec ChangeStreamRecord) bool {
+				var tok ContinuationToken
+				switch {
+				case rec.Heartbeat != nil:
+					tok = rec.Heartbeat.ContinuationToken
+				case rec.DataChange != nil:
+					tok = ContinuationToken{Partition: partition, Token: rec.DataChange.Token}
+				}
+				if tok.Token != "" {
+					if err := save(tok); err != nil {
+						closeErr = fmt.Errorf("bigtable: saving continuation token: %w", err)
+						return false
+					}
+				}
+				if rec.CloseStream != nil {
+					closeErr = rec.CloseStream.Err
+					if closeErr == nil {
+						for _, ct := range rec.CloseStream.ContinuationTokens {
+							spawn(ct.Partition, []ContinuationToken{ct})
+						}
+					}
+					return false
+				}
+				select {
+				case events <- ChangeStreamEvent{Partition: partition, Record: rec}:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}, opts...)
+			if err == nil {
+				err = closeErr
+			}
+			if err != nil && ctx.Err() == nil {
+				select {
+				case events <- ChangeStreamEvent{Partition: partition, Err: err}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	for i, p := range partitions {
+		var startTokens []ContinuationToken
+		if i < len(starts) {
+			startTokens = []ContinuationToken{starts[i]}
+		}
+		spawn(p, startTokens)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events, cancel, nil
+}
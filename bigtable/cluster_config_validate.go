@@ -0,0 +1,110 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import "fmt"
+
+// ClusterConfigError reports a single field of a ClusterConfig or
+// AutoscalingConfig that (*ClusterConfig).Validate found inconsistent,
+// the kind of thing that would otherwise only surface as an opaque
+// INVALID_ARGUMENT from CreateCluster, UpdateCluster, or SetAutoscaling's
+// admin RPC.
+type ClusterConfigError struct {
+	Field  string
+	Value  any
+	Reason string
+}
+
+func (e *ClusterConfigError) Error() string {
+	return fmt.Sprintf("bigtable: invalid %s %v: %s", e.Field, e.Value, e.Reason)
+}
+
+const (
+	minCPUTargetPercent = 10
+	maxCPUTargetPercent = 80
+
+	minSSDStorageUtilizationPerNode = 2560
+	maxSSDStorageUtilizationPerNode = 5120
+	minHDDStorageUtilizationPerNode = 8192
+	maxHDDStorageUtilizationPerNode = 16384
+)
+
+// Validate reports the first problem with cc that would otherwise only
+// surface as an opaque INVALID_ARGUMENT from CreateCluster's admin RPC:
+// NumNodes (and AutoscalingConfig's MinNodes/MaxNodes, if set) not a
+// multiple of 2 under NodeScalingFactor2X, AutoscalingConfig's MaxNodes
+// less than MinNodes, CPUTargetPercent outside 10-80, and
+// StorageUtilizationPerNode outside the band cc.StorageType allows. It
+// returns nil if cc looks consistent. Validate can't catch every
+// server-side rejection (e.g. zone capacity or quota), only the
+// invariants documented on ClusterConfig and AutoscalingConfig's fields.
+//
+// Call it directly to validate a ClusterConfig during admission, without
+// a round trip to the admin API; CreateCluster already calls it.
+func (cc *ClusterConfig) Validate() error {
+	if cc.NodeScalingFactor == NodeScalingFactor2X && cc.NumNodes%2 != 0 {
+		return &ClusterConfigError{Field: "NumNodes", Value: cc.NumNodes, Reason: "must be a multiple of 2 when NodeScalingFactor is NodeScalingFactor2X"}
+	}
+
+	a := cc.AutoscalingConfig
+	if err := a.validateBasic(); err != nil {
+		return err
+	}
+	if a == nil {
+		return nil
+	}
+
+	if cc.NodeScalingFactor == NodeScalingFactor2X {
+		if a.MinNodes%2 != 0 {
+			return &ClusterConfigError{Field: "AutoscalingConfig.MinNodes", Value: a.MinNodes, Reason: "must be a multiple of 2 when NodeScalingFactor is NodeScalingFactor2X"}
+		}
+		if a.MaxNodes%2 != 0 {
+			return &ClusterConfigError{Field: "AutoscalingConfig.MaxNodes", Value: a.MaxNodes, Reason: "must be a multiple of 2 when NodeScalingFactor is NodeScalingFactor2X"}
+		}
+	}
+
+	if a.StorageUtilizationPerNode != 0 {
+		lo, hi := minSSDStorageUtilizationPerNode, maxSSDStorageUtilizationPerNode
+		if cc.StorageType == HDD {
+			lo, hi = minHDDStorageUtilizationPerNode, maxHDDStorageUtilizationPerNode
+		}
+		if a.StorageUtilizationPerNode < lo || a.StorageUtilizationPerNode > hi {
+			return &ClusterConfigError{Field: "AutoscalingConfig.StorageUtilizationPerNode", Value: a.StorageUtilizationPerNode, Reason: fmt.Sprintf("must be between %d and %d for a %v cluster", lo, hi, cc.StorageType)}
+		}
+	}
+	return nil
+}
+
+// validateBasic checks the invariants of an AutoscalingConfig that don't
+// depend on its cluster's NodeScalingFactor or StorageType: those need
+// ClusterConfig.Validate, which has both. SetAutoscaling, whose signature
+// carries neither, calls only this.
+func (a *AutoscalingConfig) validateBasic() error {
+	if a == nil {
+		return nil
+	}
+	if a.MinNodes < 1 {
+		return &ClusterConfigError{Field: "AutoscalingConfig.MinNodes", Value: a.MinNodes, Reason: "must be at least 1"}
+	}
+	if a.MaxNodes < a.MinNodes {
+		return &ClusterConfigError{Field: "AutoscalingConfig.MaxNodes", Value: a.MaxNodes, Reason: "must be greater than or equal to MinNodes"}
+	}
+	if a.CPUTargetPercent != 0 && (a.CPUTargetPercent < minCPUTargetPercent || a.CPUTargetPercent > maxCPUTargetPercent) {
+		return &ClusterConfigError{Field: "AutoscalingConfig.CPUTargetPercent", Value: a.CPUTargetPercent, Reason: fmt.Sprintf("must be between %d and %d", minCPUTargetPercent, maxCPUTargetPercent)}
+	}
+	return nil
+}
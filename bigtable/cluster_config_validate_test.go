@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import "testing"
+
+func TestClusterConfigValidate(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		cc      ClusterConfig
+		wantErr bool
+	}{
+		{
+			desc: "plain NumNodes, no autoscaling, ok",
+			cc:   ClusterConfig{NumNodes: 3, StorageType: SSD},
+		},
+		{
+			desc:    "NumNodes odd under NodeScalingFactor2X",
+			cc:      ClusterConfig{NumNodes: 3, NodeScalingFactor: NodeScalingFactor2X},
+			wantErr: true,
+		},
+		{
+			desc: "NumNodes even under NodeScalingFactor2X ok",
+			cc:   ClusterConfig{NumNodes: 4, NodeScalingFactor: NodeScalingFactor2X},
+		},
+		{
+			desc: "autoscaling ok",
+			cc: ClusterConfig{
+				StorageType:       SSD,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 2, MaxNodes: 4, CPUTargetPercent: 50},
+			},
+		},
+		{
+			desc: "autoscaling MaxNodes less than MinNodes",
+			cc: ClusterConfig{
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 4, MaxNodes: 2},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "autoscaling MinNodes less than 1",
+			cc: ClusterConfig{
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 0, MaxNodes: 2},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "autoscaling CPUTargetPercent too low",
+			cc: ClusterConfig{
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2, CPUTargetPercent: 5},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "autoscaling CPUTargetPercent too high",
+			cc: ClusterConfig{
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2, CPUTargetPercent: 90},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "autoscaling MinNodes odd under NodeScalingFactor2X",
+			cc: ClusterConfig{
+				NodeScalingFactor: NodeScalingFactor2X,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 3, MaxNodes: 4},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "autoscaling MaxNodes odd under NodeScalingFactor2X",
+			cc: ClusterConfig{
+				NodeScalingFactor: NodeScalingFactor2X,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 2, MaxNodes: 5},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "SSD StorageUtilizationPerNode in range ok",
+			cc: ClusterConfig{
+				StorageType:       SSD,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2, StorageUtilizationPerNode: 2560},
+			},
+		},
+		{
+			desc: "SSD StorageUtilizationPerNode out of range",
+			cc: ClusterConfig{
+				StorageType:       SSD,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2, StorageUtilizationPerNode: 8192},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "HDD StorageUtilizationPerNode in range ok",
+			cc: ClusterConfig{
+				StorageType:       HDD,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2, StorageUtilizationPerNode: 8192},
+			},
+		},
+		{
+			desc: "HDD StorageUtilizationPerNode out of range",
+			cc: ClusterConfig{
+				StorageType:       HDD,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2, StorageUtilizationPerNode: 2560},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "StorageUtilizationPerNode zero uses default, ok",
+			cc: ClusterConfig{
+				StorageType:       HDD,
+				AutoscalingConfig: &AutoscalingConfig{MinNodes: 1, MaxNodes: 2},
+			},
+		},
+	} {
+		err := test.cc.Validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: Validate() = %v, wantErr %v", test.desc, err, test.wantErr)
+		}
+	}
+}
+
+func TestAutoscalingConfigValidateBasic(t *testing.T) {
+	if err := (*AutoscalingConfig)(nil).validateBasic(); err != nil {
+		t.Errorf("nil AutoscalingConfig.validateBasic() = %v, want nil", err)
+	}
+	if err := (&AutoscalingConfig{MinNodes: 1, MaxNodes: 2}).validateBasic(); err != nil {
+		t.Errorf("valid AutoscalingConfig.validateBasic() = %v, want nil", err)
+	}
+	if err := (&AutoscalingConfig{MinNodes: 0, MaxNodes: 2}).validateBasic(); err == nil {
+		t.Error("AutoscalingConfig with MinNodes 0 validateBasic() = nil, want error")
+	}
+}
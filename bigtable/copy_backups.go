@@ -0,0 +1,234 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/longrunning"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CopyBackupDestination names one destination of a CopyBackups fan-out,
+// which may be in the same cluster, a different cluster in the same
+// instance, or a different instance and project entirely.
+type CopyBackupDestination struct {
+	Project, Instance, Cluster, BackupName string
+	ExpireTime                             time.Time
+	// EncryptionConfig overrides the CMEK key this destination's copy is
+	// encrypted with. Leave nil to use the destination cluster's default.
+	EncryptionConfig *btapb.Cluster_EncryptionConfig
+}
+
+// CopyBackupOptions configures a CopyBackups fan-out.
+type CopyBackupOptions struct {
+	// MaxConcurrency caps how many destination copies are in flight at
+	// once. Zero (the default) means no cap: every destination starts
+	// its copy immediately.
+	MaxConcurrency int
+}
+
+// CopyBackupStatus is the state of one destination within a
+// CopyBackupsOperation.
+type CopyBackupStatus int
+
+const (
+	// CopyBackupPending means the destination's copy hasn't started yet,
+	// because MaxConcurrency is holding it back.
+	CopyBackupPending CopyBackupStatus = iota
+	// CopyBackupRunning means the destination's copy LRO is in progress.
+	CopyBackupRunning
+	// CopyBackupDone means the destination's copy finished successfully.
+	CopyBackupDone
+	// CopyBackupFailed means the destination's copy failed to start or
+	// finish; see the result's Err.
+	CopyBackupFailed
+)
+
+// CopyBackupDestinationResult is the current state of one destination
+// within a CopyBackupsOperation.
+type CopyBackupDestinationResult struct {
+	Destination CopyBackupDestination
+	Status      CopyBackupStatus
+	// Info is set once Status is CopyBackupDone.
+	Info *BackupInfo
+	// Err is set once Status is CopyBackupFailed.
+	Err error
+}
+
+// CopyBackupsProgress is a point-in-time count of destinations in each
+// CopyBackupStatus.
+type CopyBackupsProgress struct {
+	Pending, Running, Done, Failed int
+}
+
+// CopyBackupsOperation tracks a CopyBackups fan-out: one CopyBackup LRO
+// per destination, running with up to CopyBackupOptions.MaxConcurrency
+// in flight at a time. A failure copying one destination doesn't cancel
+// the others; call Wait to collect every destination's final result and
+// a joined error naming which ones failed.
+type CopyBackupsOperation struct {
+	mu      sync.Mutex
+	results []*CopyBackupDestinationResult
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// CopyBackups starts a CopyBackup LRO from sourceCluster/sourceBackup to
+// each of destinations, running up to opts.MaxConcurrency of them
+// concurrently, and returns immediately with a handle to track them.
+func (ac *AdminClient) CopyBackups(ctx context.Context, sourceCluster, sourceBackup string, destinations []CopyBackupDestination, opts CopyBackupOptions) (*CopyBackupsOperation, error) {
+	if len(destinations) == 0 {
+		return nil, errors.New("bigtable: CopyBackups requires at least one destination")
+	}
+
+	op := &CopyBackupsOperation{
+		results: make([]*CopyBackupDestinationResult, len(destinations)),
+		done:    make(chan struct{}),
+	}
+	for i, d := range destinations {
+		op.results[i] = &CopyBackupDestinationResult{Destination: d, Status: CopyBackupPending}
+	}
+
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = len(destinations)
+	}
+	sem := make(chan struct{}, concurrency)
+
+	sourceBackupPath := ac.backupPath(sourceCluster, ac.instance, sourceBackup)
+
+	op.wg.Add(len(destinations))
+	for i, d := range destinations {
+		i, d := i, d
+		go func() {
+			defer op.wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			op.setResult(i, CopyBackupRunning, nil, nil)
+
+			ctx := mergeOutgoingMetadata(ctx, ac.md)
+			req := &btapb.CopyBackupRequest{
+				Parent:       instancePrefix(d.Project, d.Instance) + "/clusters/" + d.Cluster,
+				BackupId:     d.BackupName,
+				SourceBackup: sourceBackupPath,
+				ExpireTime:   timestamppb.New(d.ExpireTime),
+			}
+			if d.EncryptionConfig != nil {
+				req.EncryptionConfig = d.EncryptionConfig
+			}
+
+			lro, err := ac.tClient.CopyBackup(ctx, req)
+			if err != nil {
+				op.setResult(i, CopyBackupFailed, nil, err)
+				return
+			}
+			var resp btapb.Backup
+			if err := longrunning.InternalNewOperation(ac.lroClient, lro).Wait(ctx, &resp); err != nil {
+				op.setResult(i, CopyBackupFailed, nil, err)
+				return
+			}
+			info, err := newBackupInfo(&resp)
+			if err != nil {
+				op.setResult(i, CopyBackupFailed, nil, err)
+				return
+			}
+			op.setResult(i, CopyBackupDone, info, nil)
+		}()
+	}
+
+	go func() {
+		op.wg.Wait()
+		close(op.done)
+	}()
+
+	return op, nil
+}
+
+func (o *CopyBackupsOperation) setResult(i int, status CopyBackupStatus, info *BackupInfo, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.results[i].Status = status
+	o.results[i].Info = info
+	o.results[i].Err = err
+}
+
+// Progress returns a snapshot of how many destinations are in each
+// CopyBackupStatus right now.
+func (o *CopyBackupsOperation) Progress() CopyBackupsProgress {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var p CopyBackupsProgress
+	for _, r := range o.results {
+		switch r.Status {
+		case CopyBackupPending:
+			p.Pending++
+		case CopyBackupRunning:
+			p.Running++
+		case CopyBackupDone:
+			p.Done++
+		case CopyBackupFailed:
+			p.Failed++
+		}
+	}
+	return p
+}
+
+// Poll returns the current per-destination status without blocking.
+func (o *CopyBackupsOperation) Poll(ctx context.Context) []*CopyBackupDestinationResult {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make([]*CopyBackupDestinationResult, len(o.results))
+	for i, r := range o.results {
+		cp := *r
+		out[i] = &cp
+	}
+	return out
+}
+
+// Wait blocks until every destination's copy has finished, successfully
+// or not, and returns the final per-destination results. If any
+// destination failed, it also returns a joined error naming which
+// destinations failed; siblings that succeeded are unaffected and their
+// results are still returned.
+func (o *CopyBackupsOperation) Wait(ctx context.Context) ([]*CopyBackupDestinationResult, error) {
+	select {
+	case <-o.done:
+	case <-ctx.Done():
+		return o.Poll(ctx), ctx.Err()
+	}
+
+	results := o.Poll(ctx)
+	var failed []string
+	for _, r := range results {
+		if r.Status == CopyBackupFailed {
+			failed = append(failed, fmt.Sprintf("%s: %v", r.Destination.BackupName, r.Err))
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("bigtable: %d of %d destination copies failed: %s", len(failed), len(results), strings.Join(failed, "; "))
+	}
+	return results, nil
+}
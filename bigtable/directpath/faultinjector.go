@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package directpath injects and clears network faults that emulate
+// Bigtable's DirectPath transport going dark, so integration tests can
+// exercise the client's CFE fallback. It replaces ad hoc `bash -c
+// <iptables rule>` calls with a FaultInjector interface that reports
+// failed rule installs as errors instead of logging and continuing.
+package directpath
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// FaultInjector blackholes and restores traffic to Bigtable's DirectPath
+// endpoints, for exercising the CFE-fallback path from integration
+// tests.
+type FaultInjector interface {
+	// BlackholeIPv4 drops outbound IPv4 traffic to DirectPath.
+	BlackholeIPv4() error
+	// BlackholeIPv6 drops outbound IPv6 traffic to DirectPath.
+	BlackholeIPv6() error
+	// RestoreAll undoes every Blackhole call made so far.
+	RestoreAll() error
+}
+
+// CommandFaultInjector backs FaultInjector with external commands (e.g.
+// iptables/ip6tables rules on Linux, by way of NewLinuxFaultInjector).
+// Unlike the ad hoc exec.Command calls this replaces, a non-zero exit
+// status is reported as an error rather than merely logged.
+type CommandFaultInjector struct {
+	BlackholeIPv4Cmd string
+	BlackholeIPv6Cmd string
+	RestoreIPv4Cmd   string
+	RestoreIPv6Cmd   string
+
+	// Run executes cmd in a shell and returns its combined output. It
+	// defaults to `bash -c cmd`; tests can override it to avoid actually
+	// touching the host's network rules.
+	Run func(cmd string) ([]byte, error)
+}
+
+// NewLinuxFaultInjector returns a CommandFaultInjector that installs and
+// removes the given iptables/ip6tables rules via bash.
+//
+// The request behind this package asked for nftables- or netlink-backed
+// rule installation instead of shelling out to iptables, to verify rules
+// actually land rather than trusting a command's exit status. This
+// package has no vendored copy of github.com/google/nftables (or any
+// other dependency) to build that on, so NewLinuxFaultInjector keeps the
+// shell-command approach but fixes the specific complaint raised against
+// it: a failing command now returns an error that includes its output,
+// instead of being logged and ignored. Contributors who can't or don't
+// want to install host firewall rules should use a
+// SimulatedFaultInjector instead.
+func NewLinuxFaultInjector(blackholeIPv4Cmd, blackholeIPv6Cmd, restoreIPv4Cmd, restoreIPv6Cmd string) *CommandFaultInjector {
+	return &CommandFaultInjector{
+		BlackholeIPv4Cmd: blackholeIPv4Cmd,
+		BlackholeIPv6Cmd: blackholeIPv6Cmd,
+		RestoreIPv4Cmd:   restoreIPv4Cmd,
+		RestoreIPv6Cmd:   restoreIPv6Cmd,
+	}
+}
+
+func (ci *CommandFaultInjector) run(cmd string) error {
+	if cmd == "" {
+		return fmt.Errorf("directpath: no command configured for this operation")
+	}
+	runner := ci.Run
+	if runner == nil {
+		runner = func(cmd string) ([]byte, error) { return exec.Command("bash", "-c", cmd).CombinedOutput() }
+	}
+	out, err := runner(cmd)
+	if err != nil {
+		return fmt.Errorf("directpath: command %q failed: %w (output: %s)", cmd, err, out)
+	}
+	return nil
+}
+
+// BlackholeIPv4 runs BlackholeIPv4Cmd.
+func (ci *CommandFaultInjector) BlackholeIPv4() error { return ci.run(ci.BlackholeIPv4Cmd) }
+
+// BlackholeIPv6 runs BlackholeIPv6Cmd.
+func (ci *CommandFaultInjector) BlackholeIPv6() error { return ci.run(ci.BlackholeIPv6Cmd) }
+
+// RestoreAll runs RestoreIPv4Cmd, then RestoreIPv6Cmd if it's set.
+func (ci *CommandFaultInjector) RestoreAll() error {
+	if err := ci.run(ci.RestoreIPv4Cmd); err != nil {
+		return err
+	}
+	if ci.RestoreIPv6Cmd == "" {
+		return nil
+	}
+	return ci.run(ci.RestoreIPv6Cmd)
+}
+
+// SimulatedFaultInjector blackholes DirectPath traffic in-process by
+// refusing to dial addresses under IPv4Prefixes/IPv6Prefixes while
+// blackholed, instead of manipulating host firewall rules. Wire
+// DialContext into grpc.WithContextDialer (or an equivalent net.Dialer
+// hook) to use it; it requires no host privileges, so contributors
+// without root or platform-specific firewall tooling can still run
+// DirectPath-fallback tests.
+type SimulatedFaultInjector struct {
+	IPv4Prefixes []string
+	IPv6Prefixes []string
+
+	mu         sync.Mutex
+	blackholed bool
+}
+
+// BlackholeIPv4 starts refusing dials to IPv4Prefixes.
+func (si *SimulatedFaultInjector) BlackholeIPv4() error { si.setBlackholed(true); return nil }
+
+// BlackholeIPv6 starts refusing dials to IPv6Prefixes.
+//
+// The simulated injector doesn't distinguish which address family
+// triggered the blackhole: once either is called, DialContext refuses
+// both families' prefixes until RestoreAll.
+func (si *SimulatedFaultInjector) BlackholeIPv6() error { si.setBlackholed(true); return nil }
+
+// RestoreAll stops refusing dials.
+func (si *SimulatedFaultInjector) RestoreAll() error { si.setBlackholed(false); return nil }
+
+func (si *SimulatedFaultInjector) setBlackholed(v bool) {
+	si.mu.Lock()
+	si.blackholed = v
+	si.mu.Unlock()
+}
+
+// DialContext is a net.Dialer-shaped dial function suitable for
+// grpc.WithContextDialer. While blackholed, it refuses connections to
+// addr if its host matches one of IPv4Prefixes/IPv6Prefixes, and
+// otherwise dials normally.
+func (si *SimulatedFaultInjector) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	si.mu.Lock()
+	blackholed := si.blackholed
+	si.mu.Unlock()
+	if blackholed && si.matches(addr) {
+		return nil, fmt.Errorf("directpath: simulated blackhole refused connection to %s", addr)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, network, addr)
+}
+
+func (si *SimulatedFaultInjector) matches(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	for _, p := range si.IPv4Prefixes {
+		if strings.HasPrefix(host, p) {
+			return true
+		}
+	}
+	for _, p := range si.IPv6Prefixes {
+		if strings.HasPrefix(host, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupportedFaultInjector reports every call as an error naming the
+// unsupported platform.
+type unsupportedFaultInjector struct{ platform string }
+
+// NewPfctlFaultInjector would back FaultInjector with pfctl anchor rules
+// on macOS. This package has no pfctl-driving code in this snapshot, so
+// it returns a FaultInjector whose every method fails explicitly rather
+// than silently no-opping; macOS contributors should use a
+// SimulatedFaultInjector instead.
+func NewPfctlFaultInjector() FaultInjector {
+	return unsupportedFaultInjector{platform: "macOS (pfctl)"}
+}
+
+// NewWFPFaultInjector would back FaultInjector with Windows Filtering
+// Platform rules. This package has no WFP-driving code in this snapshot,
+// so it returns a FaultInjector whose every method fails explicitly
+// rather than silently no-opping; Windows contributors should use a
+// SimulatedFaultInjector instead.
+func NewWFPFaultInjector() FaultInjector {
+	return unsupportedFaultInjector{platform: "Windows (WFP)"}
+}
+
+func (u unsupportedFaultInjector) BlackholeIPv4() error { return u.err() }
+func (u unsupportedFaultInjector) BlackholeIPv6() error { return u.err() }
+func (u unsupportedFaultInjector) RestoreAll() error    { return u.err() }
+
+func (u unsupportedFaultInjector) err() error {
+	return fmt.Errorf("directpath: fault injection on %s isn't implemented in this package; use a SimulatedFaultInjector instead", u.platform)
+}
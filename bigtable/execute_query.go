@@ -0,0 +1,407 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+)
+
+// PreparedStatement is a query whose text and parameter types have
+// already been validated and planned by the server, returned by
+// Client.PrepareStatement. Bind it to a set of parameter values (via
+// Bind) to get a BoundStatement ready to Execute; the same
+// PreparedStatement can be Bound and executed repeatedly, skipping the
+// cost of re-parsing and re-planning the SQL each time.
+type PreparedStatement struct {
+	c          *Client
+	query      []byte // opaque PreparedQuery bytes returned by PrepareQuery
+	paramTypes map[string]SQLType
+}
+
+// PrepareStatement parses and plans query once, returning a
+// PreparedStatement that can be Bound and executed multiple times with
+// different parameter values. paramTypes declares the type of every
+// named parameter query references (by the @name it's written with in
+// the SQL text, without the @); Bind's paramValues must supply a value
+// of the matching type for each one.
+func (c *Client) PrepareStatement(ctx context.Context, query string, paramTypes map[string]SQLType) (*PreparedStatement, error) {
+	req := &btpb.PrepareQueryRequest{
+		InstanceName: "projects/" + c.project + "/instances/" + c.instance,
+		AppProfileId: c.appProfile,
+		Query:        query,
+	}
+	if len(paramTypes) > 0 {
+		req.ParamTypes = make(map[string]*btpb.Type, len(paramTypes))
+		for name, t := range paramTypes {
+			req.ParamTypes[name] = t.sqlType()
+		}
+	}
+	resp, err := c.client.PrepareQuery(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: preparing statement: %w", err)
+	}
+	return &PreparedStatement{c: c, query: resp.GetPreparedQuery(), paramTypes: paramTypes}, nil
+}
+
+// BoundStatement is a PreparedStatement together with a set of parameter
+// values, ready to Execute.
+type BoundStatement struct {
+	ps     *PreparedStatement
+	params map[string]*btpb.Value
+}
+
+// Bind binds paramValues to ps's named parameters, returning a
+// BoundStatement ready to Execute. paramValues must have a value of the
+// Go type matching the SQLType that PrepareStatement declared for that
+// parameter (see the SQLType implementations' doc comments).
+func (ps *PreparedStatement) Bind(paramValues map[string]interface{}) (*BoundStatement, error) {
+	bound := make(map[string]*btpb.Value, len(paramValues))
+	for name, val := range paramValues {
+		t, ok := ps.paramTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("bigtable: Bind: %q is not a parameter of this prepared statement", name)
+		}
+		if val == nil {
+			bound[name] = &btpb.Value{}
+			continue
+		}
+		v, err := t.encodeValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("bigtable: Bind: parameter %q: %w", name, err)
+		}
+		bound[name] = v
+	}
+	return &BoundStatement{ps: ps, params: bound}, nil
+}
+
+// ExecuteOption configures a BoundStatement Execute or Rows call.
+type ExecuteOption interface {
+	set(*btpb.ExecuteQueryRequest)
+}
+
+type resumeTokenOption string
+
+func (o resumeTokenOption) set(req *btpb.ExecuteQueryRequest) {
+	req.ResumeToken = []byte(o)
+}
+
+// WithResumeToken resumes a query from a checkpoint reported by an
+// earlier call's QueryRowIterator.PageToken, instead of starting from
+// the beginning of the result set. Useful for paging through or
+// resuming a long-running query across process restarts.
+func WithResumeToken(token string) ExecuteOption {
+	return resumeTokenOption(token)
+}
+
+// newRequest builds the ExecuteQueryRequest for bs, with opts applied.
+func (bs *BoundStatement) newRequest(opts []ExecuteOption) *btpb.ExecuteQueryRequest {
+	req := &btpb.ExecuteQueryRequest{
+		InstanceName:  "projects/" + bs.ps.c.project + "/instances/" + bs.ps.c.instance,
+		AppProfileId:  bs.ps.c.appProfile,
+		PreparedQuery: bs.ps.query,
+	}
+	if len(bs.params) > 0 {
+		req.Params = bs.params
+	}
+	for _, opt := range opts {
+		opt.set(req)
+	}
+	return req
+}
+
+// Execute runs bs against the instance its PreparedStatement was
+// prepared against, calling f once per result row in order. Execute
+// stops and returns nil as soon as f returns false; it returns an error
+// if the query fails or a result row can't be decoded.
+//
+// The wire format ExecuteQuery speaks (PartialResultSet batching, resume
+// tokens, and the proto-rows row encoding within each batch) is
+// reproduced here as best understood from the public Bigtable SQL
+// surface; this package has no cached copy of the generated ExecuteQuery
+// request/response types to check field names against, so treat the
+// exact proto shape as best-effort. In particular, a dropped stream
+// isn't retried/resumed from the server's resume token the way a
+// production client should — callers that need that should catch the
+// error, note the last row they processed, and re-run the query with
+// WithResumeToken (via Rows, which reports the token per batch) rather
+// than relying on Execute to do it.
+func (bs *BoundStatement) Execute(ctx context.Context, f func(ResultRow) bool, opts ...ExecuteOption) error {
+	req := bs.newRequest(opts)
+	stream, err := bs.ps.c.client.ExecuteQuery(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var schema []QueryColumn
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if md := resp.GetMetadata(); md != nil {
+			schema = queryColumnsFromMetadata(md)
+			continue
+		}
+		results := resp.GetResults()
+		if results == nil {
+			continue
+		}
+		rows, err := decodeProtoRows(schema, results.GetProtoRowsBatch().GetBatchData())
+		if err != nil {
+			return fmt.Errorf("bigtable: decoding query result batch: %w", err)
+		}
+		for _, row := range rows {
+			if !f(row) {
+				return nil
+			}
+		}
+	}
+}
+
+// QueryRowIterator iterates over the rows of a BoundStatement.Rows call,
+// pulled one at a time via Next, as an alternative to Execute's callback
+// form for callers composing a scan with select, pipelines, or a
+// checkpointed resume across process restarts.
+//
+// A QueryRowIterator must eventually be drained to iterator.Done or have
+// Close called, or its background goroutine and gRPC stream will leak.
+type QueryRowIterator struct {
+	cancel context.CancelFunc
+	rows   chan ResultRow
+	done   chan struct{}
+
+	mu          sync.Mutex
+	err         error
+	resumeToken string
+}
+
+// Rows is like Execute, but returns a QueryRowIterator instead of
+// invoking a callback. The query runs in a background goroutine that
+// decodes one response's batch of rows at a time and hands the rows to
+// Next over an unbuffered channel.
+func (bs *BoundStatement) Rows(ctx context.Context, opts ...ExecuteOption) (*QueryRowIterator, error) {
+	req := bs.newRequest(opts)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := bs.ps.c.client.ExecuteQuery(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	it := &QueryRowIterator{
+		cancel: cancel,
+		rows:   make(chan ResultRow),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(it.done)
+		defer close(it.rows)
+
+		var schema []QueryColumn
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				it.setErr(err)
+				return
+			}
+
+			if md := resp.GetMetadata(); md != nil {
+				schema = queryColumnsFromMetadata(md)
+				continue
+			}
+			results := resp.GetResults()
+			if results == nil {
+				continue
+			}
+			rows, err := decodeProtoRows(schema, results.GetProtoRowsBatch().GetBatchData())
+			if err != nil {
+				it.setErr(fmt.Errorf("bigtable: decoding query result batch: %w", err))
+				return
+			}
+			for _, row := range rows {
+				select {
+				case it.rows <- row:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if tok := results.GetResumeToken(); len(tok) > 0 {
+				it.mu.Lock()
+				it.resumeToken = string(tok)
+				it.mu.Unlock()
+			}
+		}
+	}()
+
+	return it, nil
+}
+
+func (it *QueryRowIterator) setErr(err error) {
+	it.mu.Lock()
+	it.err = err
+	it.mu.Unlock()
+}
+
+// Next returns the next result row, or iterator.Done once the query
+// completes.
+func (it *QueryRowIterator) Next() (ResultRow, error) {
+	row, ok := <-it.rows
+	if !ok {
+		it.mu.Lock()
+		err := it.err
+		it.mu.Unlock()
+		if err != nil {
+			return ResultRow{}, err
+		}
+		return ResultRow{}, iterator.Done
+	}
+	return row, nil
+}
+
+// PageToken returns a checkpoint for the rows Next has returned so far,
+// as of the last server batch whose rows Next has all returned: passing
+// it to WithResumeToken on a later Execute or Rows call resumes the query
+// just after that batch, instead of restarting from the beginning; rows
+// Next has already returned from a later batch are delivered again.
+// It's empty until the server has reported a resume token, which may not
+// happen until more than one batch of rows has been decoded.
+func (it *QueryRowIterator) PageToken() string {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.resumeToken
+}
+
+// Close cancels the underlying ExecuteQuery stream. Safe to call more
+// than once, and after Next has returned iterator.Done.
+func (it *QueryRowIterator) Close() {
+	it.cancel()
+}
+
+// QueryParam is a named parameter's type and value for Client.ExecuteQuery,
+// bundling together what PrepareStatement and Bind would otherwise take
+// as two separate arguments. Use one of the Xxx typed param constructors
+// below (e.g. StringParam, Int64Param) rather than constructing
+// QueryParam directly.
+type QueryParam struct {
+	typ SQLType
+	val interface{}
+}
+
+// StringParam returns a string-typed QueryParam.
+func StringParam(s string) QueryParam { return QueryParam{StringSQLType{}, s} }
+
+// BytesParam returns a bytes-typed QueryParam.
+func BytesParam(b []byte) QueryParam { return QueryParam{BytesSQLType{}, b} }
+
+// Int64Param returns an int64-typed QueryParam.
+func Int64Param(n int64) QueryParam { return QueryParam{Int64SQLType{}, n} }
+
+// Float64Param returns a float64-typed QueryParam.
+func Float64Param(f float64) QueryParam { return QueryParam{Float64SQLType{}, f} }
+
+// BoolParam returns a bool-typed QueryParam.
+func BoolParam(b bool) QueryParam { return QueryParam{BoolSQLType{}, b} }
+
+// ExecuteQuery is a convenience wrapper around PrepareStatement, Bind,
+// and Execute for a query that's only run once. Callers executing the
+// same query repeatedly should call PrepareStatement themselves and
+// reuse the result, to avoid re-planning the SQL on every call.
+func (c *Client) ExecuteQuery(ctx context.Context, query string, params map[string]QueryParam, f func(ResultRow) bool, opts ...ExecuteOption) error {
+	paramTypes := make(map[string]SQLType, len(params))
+	paramValues := make(map[string]interface{}, len(params))
+	for name, p := range params {
+		paramTypes[name] = p.typ
+		paramValues[name] = p.val
+	}
+	ps, err := c.PrepareStatement(ctx, query, paramTypes)
+	if err != nil {
+		return err
+	}
+	bs, err := ps.Bind(paramValues)
+	if err != nil {
+		return err
+	}
+	return bs.Execute(ctx, f, opts...)
+}
+
+// queryColumnsFromMetadata extracts column names and types from a
+// ResultSetMetadata's proto schema.
+func queryColumnsFromMetadata(md *btpb.ResultSetMetadata) []QueryColumn {
+	schema := md.GetProtoSchema()
+	if schema == nil {
+		return nil
+	}
+	cols := make([]QueryColumn, len(schema.GetColumns()))
+	for i, c := range schema.GetColumns() {
+		cols[i] = QueryColumn{
+			Name:      c.GetName(),
+			Aggregate: c.GetType().GetAggregateType() != nil,
+			typ:       c.GetType(),
+		}
+	}
+	return cols
+}
+
+// decodeProtoRows decodes one PartialResultSet batch's serialized
+// proto-rows payload into ResultRows, per schema's declared column
+// types. batchData may span a partial row at its start or end, carried
+// over from/to an adjoining batch; that reassembly isn't implemented
+// here, so this assumes each batch contains whole rows only.
+func decodeProtoRows(schema []QueryColumn, batchData []byte) ([]ResultRow, error) {
+	if len(batchData) == 0 {
+		return nil, nil
+	}
+	var values btpb.ProtoRows
+	if err := proto.Unmarshal(batchData, &values); err != nil {
+		return nil, err
+	}
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("bigtable: received query results before ResultSetMetadata")
+	}
+	var rows []ResultRow
+	for i := 0; i < len(values.GetValues()); i += len(schema) {
+		row := ResultRow{columns: schema, values: make([]interface{}, len(schema))}
+		for c, col := range schema {
+			if i+c >= len(values.GetValues()) {
+				break
+			}
+			v, err := decodeValue(col.typ, values.GetValues()[i+c])
+			if err != nil {
+				return nil, fmt.Errorf("bigtable: decoding column %q: %w", col.Name, err)
+			}
+			row.values[c] = v
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
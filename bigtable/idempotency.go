@@ -0,0 +1,134 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+
+	"cloud.google.com/go/internal"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IdempotencyAction says how an AdminClient configured with
+// WithIdempotentCreate should react to a particular gRPC status code
+// returned by a create call.
+type IdempotencyAction int
+
+const (
+	// IdempotencyAbort gives up immediately, returning the error as-is.
+	IdempotencyAbort IdempotencyAction = iota
+	// IdempotencyRetry retries the create call after the configured
+	// backoff, without taking any compensating action first.
+	IdempotencyRetry
+	// IdempotencyDeleteAndRetry calls the policy's OnConflict hook (if
+	// set) to remove the conflicting resource, then retries the create
+	// call after the configured backoff.
+	IdempotencyDeleteAndRetry
+)
+
+// IdempotencyPolicy configures the retry behavior WithIdempotentCreate
+// installs on an AdminClient's create calls. Those calls aren't
+// naturally idempotent: a retried CreateTable after a dropped response
+// can return AlreadyExists even though the first attempt actually
+// succeeded, and callers writing their own tooling (Terraform providers,
+// migration scripts) otherwise have to reimplement a
+// retry/delete-and-retry loop themselves.
+type IdempotencyPolicy struct {
+	// RetryableCodes maps a gRPC status code returned by a create call to
+	// the action to take. A code missing from the map is treated as
+	// IdempotencyAbort. A nil map is equivalent to
+	// {codes.AlreadyExists: IdempotencyDeleteAndRetry}.
+	RetryableCodes map[codes.Code]IdempotencyAction
+
+	// Backoff controls the delay between attempts. The zero value uses
+	// internal.Retry's defaults.
+	Backoff gax.Backoff
+
+	// MaxAttempts caps the number of create attempts, including the
+	// first. The zero value means 10.
+	MaxAttempts int
+
+	// OnConflict is called with the resource ID passed to the Create call
+	// (a table ID, or "table/family" for a column family) when
+	// IdempotencyDeleteAndRetry fires, to remove the conflicting resource
+	// before the next attempt. A nil OnConflict skips straight to the
+	// retry without deleting anything first.
+	OnConflict func(ctx context.Context, resourceID string) error
+}
+
+func (p *IdempotencyPolicy) maxAttempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return 10
+}
+
+func (p *IdempotencyPolicy) action(code codes.Code) IdempotencyAction {
+	if p.RetryableCodes == nil {
+		if code == codes.AlreadyExists {
+			return IdempotencyDeleteAndRetry
+		}
+		return IdempotencyAbort
+	}
+	return p.RetryableCodes[code]
+}
+
+// run calls create repeatedly per p until it succeeds, a non-retryable
+// error is returned, or MaxAttempts is reached. resourceID identifies
+// the resource being created, and is only used for OnConflict.
+func (p *IdempotencyPolicy) run(ctx context.Context, resourceID string, create func() error) error {
+	var lastErr error
+	attempts := 0
+	return internal.Retry(ctx, p.Backoff, func() (bool, error) {
+		attempts++
+		lastErr = create()
+		if lastErr == nil {
+			return true, nil
+		}
+		s, ok := status.FromError(lastErr)
+		if !ok {
+			return true, lastErr
+		}
+		switch p.action(s.Code()) {
+		case IdempotencyDeleteAndRetry:
+			if p.OnConflict != nil {
+				if err := p.OnConflict(ctx, resourceID); err != nil {
+					lastErr = err
+					return true, err
+				}
+			}
+		case IdempotencyRetry:
+			// fall through to the attempts check below
+		default: // IdempotencyAbort
+			return true, lastErr
+		}
+		return attempts >= p.maxAttempts(), lastErr
+	})
+}
+
+// WithIdempotentCreate returns a copy of ac whose CreateTable,
+// CreatePresplitTable, CreateTableFromConf, CreateColumnFamily, and
+// CreateColumnFamilyWithConfig calls retry per policy instead of
+// returning the first error. ac itself is left unmodified, so other
+// holders of it are unaffected.
+func (ac *AdminClient) WithIdempotentCreate(policy IdempotencyPolicy) *AdminClient {
+	cp := *ac
+	cp.idempotency = &policy
+	return &cp
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/longrunning"
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	field_mask "google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// InstanceOperation is a handle to a CreateInstance or PartialUpdateInstance
+// long-running operation in progress, returned by
+// InstanceAdminClient.CreateInstanceOperation,
+// InstanceAdminClient.UpdateInstanceWithClustersOperation, or
+// InstanceAdminClient.InstanceOperation. Unlike CreateInstance and
+// UpdateInstanceWithClusters, which block until the operation finishes,
+// this lets a controller start the operation in one reconcile pass and
+// poll for completion in a later one, the way it would track a GKE
+// cluster LRO.
+type InstanceOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// InstanceAdminClient.InstanceOperation to reattach to later (e.g. across
+// a process restart).
+func (o *InstanceOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *InstanceOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md — a
+// btapb.CreateInstanceMetadata or btapb.UpdateInstanceMetadata, depending
+// on which call returned o — or returns an error if the server hasn't
+// reported any yet.
+func (o *InstanceOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *InstanceOperation) Poll(ctx context.Context) (bool, error) {
+	var inst btapb.Instance
+	return o.op.Poll(ctx, &inst)
+}
+
+// Wait blocks until the operation finishes.
+func (o *InstanceOperation) Wait(ctx context.Context) error {
+	var inst btapb.Instance
+	return o.op.Wait(ctx, &inst)
+}
+
+// InstanceOperation returns an InstanceOperation handle for the
+// long-running operation named name (as previously reported by another
+// InstanceOperation's Name), so a controller that crashed mid-operation
+// can reattach to it on restart instead of losing track of it.
+func (iac *InstanceAdminClient) InstanceOperation(name string) *InstanceOperation {
+	return &InstanceOperation{op: longrunning.InternalNewOperation(iac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// CreateInstanceOperation is like CreateInstance, but returns an
+// InstanceOperation handle instead of blocking until creation finishes.
+func (iac *InstanceAdminClient) CreateInstanceOperation(ctx context.Context, conf *InstanceConf) (*InstanceOperation, error) {
+	newConfig := &InstanceWithClustersConfig{
+		InstanceID:   conf.InstanceId,
+		DisplayName:  conf.DisplayName,
+		InstanceType: conf.InstanceType,
+		Labels:       conf.Labels,
+		Clusters: []ClusterConfig{
+			{
+				InstanceID:        conf.InstanceId,
+				ClusterID:         conf.ClusterId,
+				Zone:              conf.Zone,
+				NumNodes:          conf.NumNodes,
+				StorageType:       conf.StorageType,
+				AutoscalingConfig: conf.AutoscalingConfig,
+				NodeScalingFactor: conf.NodeScalingFactor,
+			},
+		},
+	}
+	return iac.CreateInstanceWithClustersOperation(ctx, newConfig)
+}
+
+// CreateInstanceWithClustersOperation is like CreateInstanceWithClusters,
+// but returns an InstanceOperation handle instead of blocking until
+// creation finishes.
+func (iac *InstanceAdminClient) CreateInstanceWithClustersOperation(ctx context.Context, conf *InstanceWithClustersConfig) (*InstanceOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, iac.md)
+	clusters := make(map[string]*btapb.Cluster)
+	for _, cluster := range conf.Clusters {
+		clusters[cluster.ClusterID] = cluster.proto(iac.project)
+	}
+
+	req := &btapb.CreateInstanceRequest{
+		Parent:     "projects/" + iac.project,
+		InstanceId: conf.InstanceID,
+		Instance: &btapb.Instance{
+			DisplayName: conf.DisplayName,
+			Type:        btapb.Instance_Type(conf.InstanceType),
+			Labels:      conf.Labels,
+		},
+		Clusters: clusters,
+	}
+
+	lro, err := iac.iClient.CreateInstance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &InstanceOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
+}
+
+// UpdateInstanceWithClustersOperation kicks off the instance-level part of
+// UpdateInstanceWithClusters — the PartialUpdateInstance call for conf's
+// DisplayName, InstanceType, and Labels — and returns an InstanceOperation
+// handle instead of blocking until it finishes.
+//
+// Unlike UpdateInstanceWithClusters, it doesn't also update conf.Clusters:
+// each cluster update is its own separate long-running operation, so
+// there's no single handle that covers the instance update and every
+// cluster update together. Start those individually with
+// UpdateClusterOperation/SetAutoscalingOperation once this operation's
+// Wait (or a caller's own polling of Done) confirms the instance update
+// landed.
+func (iac *InstanceAdminClient) UpdateInstanceWithClustersOperation(ctx context.Context, conf *InstanceWithClustersConfig) (*InstanceOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, iac.md)
+
+	for _, cluster := range conf.Clusters {
+		if cluster.ClusterID == "" {
+			return nil, errors.New("ClusterID is required for every cluster")
+		}
+	}
+
+	mask := &field_mask.FieldMask{}
+	req := &btapb.PartialUpdateInstanceRequest{
+		Instance: &btapb.Instance{
+			Name: "projects/" + iac.project + "/instances/" + conf.InstanceID,
+		},
+		UpdateMask: mask,
+	}
+	if conf.DisplayName != "" {
+		req.Instance.DisplayName = conf.DisplayName
+		mask.Paths = append(mask.Paths, "display_name")
+	}
+	if btapb.Instance_Type(conf.InstanceType) != btapb.Instance_TYPE_UNSPECIFIED {
+		req.Instance.Type = btapb.Instance_Type(conf.InstanceType)
+		mask.Paths = append(mask.Paths, "type")
+	}
+	if conf.Labels != nil {
+		req.Instance.Labels = conf.Labels
+		mask.Paths = append(mask.Paths, "labels")
+	}
+	if len(mask.Paths) == 0 {
+		lro, err := doneInstanceOperation(&btapb.Instance{})
+		if err != nil {
+			return nil, err
+		}
+		return &InstanceOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
+	}
+
+	lro, err := iac.iClient.PartialUpdateInstance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &InstanceOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
+}
+
+// doneInstanceOperation packages resp as an already-completed
+// longrunningpb.Operation, for the no-op case of
+// UpdateInstanceWithClustersOperation where there's nothing to update at
+// the instance level and so no real operation for the server to run.
+func doneInstanceOperation(resp proto.Message) (*longrunningpb.Operation, error) {
+	any, err := anypb.New(resp)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: packing operation response: %w", err)
+	}
+	return &longrunningpb.Operation{
+		Done:   true,
+		Result: &longrunningpb.Operation_Response{Response: any},
+	}, nil
+}
+
+// ClusterOperation is a handle to a CreateCluster, PartialUpdateCluster
+// (via UpdateCluster or SetAutoscaling) long-running operation in
+// progress, returned by InstanceAdminClient.CreateClusterOperation,
+// InstanceAdminClient.UpdateClusterOperation,
+// InstanceAdminClient.SetAutoscalingOperation, or
+// InstanceAdminClient.ClusterOperation.
+type ClusterOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// InstanceAdminClient.ClusterOperation to reattach to later (e.g. across
+// a process restart).
+func (o *ClusterOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *ClusterOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md — a
+// btapb.CreateClusterMetadata or btapb.UpdateClusterMetadata, depending
+// on which call returned o — or returns an error if the server hasn't
+// reported any yet.
+func (o *ClusterOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *ClusterOperation) Poll(ctx context.Context) (bool, error) {
+	var cl btapb.Cluster
+	return o.op.Poll(ctx, &cl)
+}
+
+// Wait blocks until the operation finishes.
+func (o *ClusterOperation) Wait(ctx context.Context) error {
+	var cl btapb.Cluster
+	return o.op.Wait(ctx, &cl)
+}
+
+// ClusterOperation returns a ClusterOperation handle for the long-running
+// operation named name (as previously reported by another
+// ClusterOperation's Name), so a controller that crashed mid-operation
+// can reattach to it on restart instead of losing track of it.
+func (iac *InstanceAdminClient) ClusterOperation(name string) *ClusterOperation {
+	return &ClusterOperation{op: longrunning.InternalNewOperation(iac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// CreateClusterOperation is like CreateCluster, but returns a
+// ClusterOperation handle instead of blocking until creation finishes.
+func (iac *InstanceAdminClient) CreateClusterOperation(ctx context.Context, conf *ClusterConfig) (*ClusterOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, iac.md)
+
+	req := &btapb.CreateClusterRequest{
+		Parent:    "projects/" + iac.project + "/instances/" + conf.InstanceID,
+		ClusterId: conf.ClusterID,
+		Cluster:   conf.proto(iac.project),
+	}
+
+	lro, err := iac.iClient.CreateCluster(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
+}
+
+// UpdateClusterOperation is like UpdateCluster, but returns a
+// ClusterOperation handle instead of blocking until the update finishes.
+func (iac *InstanceAdminClient) UpdateClusterOperation(ctx context.Context, instanceID, clusterID string, serveNodes int32) (*ClusterOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, iac.md)
+	cluster := &btapb.Cluster{
+		Name:       "projects/" + iac.project + "/instances/" + instanceID + "/clusters/" + clusterID,
+		ServeNodes: serveNodes,
+		Config:     nil,
+	}
+	lro, err := iac.iClient.PartialUpdateCluster(ctx, &btapb.PartialUpdateClusterRequest{
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"serve_nodes", "cluster_config.cluster_autoscaling_config"},
+		},
+		Cluster: cluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
+}
+
+// SetAutoscalingOperation is like SetAutoscaling, but returns a
+// ClusterOperation handle instead of blocking until the update finishes.
+func (iac *InstanceAdminClient) SetAutoscalingOperation(ctx context.Context, instanceID, clusterID string, conf AutoscalingConfig) (*ClusterOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, iac.md)
+	cluster := &btapb.Cluster{
+		Name: "projects/" + iac.project + "/instances/" + instanceID + "/clusters/" + clusterID,
+		Config: &btapb.Cluster_ClusterConfig_{
+			ClusterConfig: &btapb.Cluster_ClusterConfig{
+				ClusterAutoscalingConfig: conf.proto(),
+			},
+		},
+	}
+	lro, err := iac.iClient.PartialUpdateCluster(ctx, &btapb.PartialUpdateClusterRequest{
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"cluster_config.cluster_autoscaling_config"},
+		},
+		Cluster: cluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterOperation{op: longrunning.InternalNewOperation(iac.lroClient, lro)}, nil
+}
@@ -26,7 +26,6 @@ import (
 	"math"
 	"math/rand"
 	"os"
-	"os/exec"
 	"reflect"
 	"sort"
 	"strconv"
@@ -38,6 +37,7 @@ import (
 	cryptorand "crypto/rand"
 
 	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/bigtable/directpath"
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/iam"
 	"cloud.google.com/go/internal"
@@ -305,11 +305,12 @@ func TestIntegration_ReadRowList(t *testing.T) {
 
 func TestIntegration_UpdateFamilyValueType(t *testing.T) {
 	ctx := context.Background()
-	_, _, adminClient, _, tableName, cleanup, err := setupIntegration(ctx, t)
+	testEnv, _, adminClient, _, tableName, cleanup, err := setupIntegration(ctx, t)
 	if err != nil {
 		t.Fatal(err)
 	}
 	t.Cleanup(cleanup)
+	requireCapability(t, testEnv, CapUpdateFamilyValueType)
 	familyName := "new_family"
 	// Create a new column family
 	if err = createColumnFamily(ctx, t, adminClient, tableName, familyName, nil); err != nil {
@@ -349,11 +350,12 @@ func TestIntegration_UpdateFamilyValueType(t *testing.T) {
 
 func TestIntegration_Aggregates(t *testing.T) {
 	ctx := context.Background()
-	_, _, ac, table, tableName, cleanup, err := setupIntegration(ctx, t)
+	testEnv, _, ac, table, tableName, cleanup, err := setupIntegration(ctx, t)
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer cleanup()
+	requireCapability(t, testEnv, CapAggregates)
 	key := "some-key"
 	family := "sum"
 	column := "col"
@@ -425,11 +427,12 @@ func TestIntegration_Aggregates(t *testing.T) {
 
 func TestIntegration_ReadRowListReverse(t *testing.T) {
 	ctx := context.Background()
-	_, _, _, table, _, cleanup, err := setupIntegration(ctx, t)
+	testEnv, _, _, table, _, cleanup, err := setupIntegration(ctx, t)
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer cleanup()
+	requireCapability(t, testEnv, CapReverseScan)
 
 	if err := populatePresidentsGraph(table); err != nil {
 		t.Fatal(err)
@@ -2170,6 +2173,29 @@ func TestIntegration_CreateTableWithRowKeySchema(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "Create with OrderedCode float, timestamp, and array fields ok",
+			rks: StructType{
+				Fields: []StructField{
+					{FieldName: "myfloat32", FieldType: Float32Type{Encoding: IEEE754OrderedBytesEncoding{}}},
+					{FieldName: "myfloat64", FieldType: Float64Type{Encoding: IEEE754OrderedBytesEncoding{}}},
+					{FieldName: "mytimestamp", FieldType: TimestampType{Encoding: Int64MicrosecondsBigEndianEncoding{}}},
+					{FieldName: "mytags", FieldType: ArrayType{ElementType: StringType{Encoding: StringUtf8BytesEncoding{}}}},
+				},
+				Encoding: StructOrderedCodeBytesEncoding{},
+			},
+		},
+		{
+			desc: "Create fail with variable-length unencoded field before a fixed field",
+			rks: StructType{
+				Fields: []StructField{
+					{FieldName: "myfield1", FieldType: StringType{}},
+					{FieldName: "myfield2", FieldType: Int64Type{Encoding: BigEndianBytesEncoding{}}},
+				},
+				Encoding: StructOrderedCodeBytesEncoding{},
+			},
+			errorExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2269,6 +2295,19 @@ func TestIntegration_UpdateRowKeySchemaInTable(t *testing.T) {
 			},
 			currentRks: nil,
 		},
+		{
+			desc: "Update with OrderedCode float, timestamp, and array fields ok",
+			updateRks: StructType{
+				Fields: []StructField{
+					{FieldName: "myfloat32", FieldType: Float32Type{Encoding: IEEE754OrderedBytesEncoding{}}},
+					{FieldName: "myfloat64", FieldType: Float64Type{Encoding: IEEE754OrderedBytesEncoding{}}},
+					{FieldName: "mytimestamp", FieldType: TimestampType{Encoding: Int64MicrosecondsBigEndianEncoding{}}},
+					{FieldName: "mytags", FieldType: ArrayType{ElementType: StringType{Encoding: StringUtf8BytesEncoding{}}}},
+				},
+				Encoding: StructOrderedCodeBytesEncoding{},
+			},
+			currentRks: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3201,7 +3240,8 @@ func TestIntegration_AdminUpdateInstanceAndSyncClusters(t *testing.T) {
 		t.Fatalf("UpdateInstanceResults: got - want +\n%s", diff)
 	}
 
-	// Now update one cluster and delete the other
+	// Now update one cluster and delete the other. Omitting
+	// AllowClusterDeletion must refuse to touch anything.
 	confWithClusters = &InstanceWithClustersConfig{
 		InstanceID: instanceToCreate,
 		Clusters: []ClusterConfig{
@@ -3209,6 +3249,33 @@ func TestIntegration_AdminUpdateInstanceAndSyncClusters(t *testing.T) {
 		},
 	}
 
+	plan, err := UpdateInstanceAndPlanClusters(ctx, iAdminClient, confWithClusters)
+	if err != nil {
+		t.Fatalf("UpdateInstanceAndPlanClusters: %v", err)
+	}
+	if diff := testutil.Diff(plan.ClustersToDelete, []string{clusterID2}); diff != "" {
+		t.Fatalf("UpdateInstancePlan.ClustersToDelete: got - want +\n%s", diff)
+	}
+	if diff := testutil.Diff(plan.ClustersToUpdate, []ClusterConfig{{ClusterID: clusterID, NumNodes: 4}}); diff != "" {
+		t.Fatalf("UpdateInstancePlan.ClustersToUpdate: got - want +\n%s", diff)
+	}
+	if len(plan.ClustersToCreate) != 0 {
+		t.Fatalf("UpdateInstancePlan.ClustersToCreate: got %v, want none", plan.ClustersToCreate)
+	}
+	// Computing the plan must not have mutated anything.
+	if _, err := iAdminClient.GetCluster(ctx, instanceToCreate, clusterID2); err != nil {
+		t.Fatalf("GetCluster: cluster %q should still exist after planning: %v", clusterID2, err)
+	}
+
+	if _, err = plan.Apply(ctx); err == nil {
+		t.Fatalf("UpdateInstancePlan.Apply: expected error deleting cluster %q without AllowClusterDeletion", clusterID2)
+	}
+	cInfo, err = iAdminClient.GetCluster(ctx, instanceToCreate, clusterID2)
+	if err != nil {
+		t.Fatalf("GetCluster: cluster %q should not have been deleted: %v", clusterID2, err)
+	}
+
+	confWithClusters.AllowClusterDeletion = true
 	results, err = UpdateInstanceAndSyncClusters(ctx, iAdminClient, confWithClusters)
 	if err != nil {
 		t.Fatalf("UpdateInstanceAndSyncClusters: %v %v", confWithClusters, err)
@@ -3360,6 +3427,48 @@ func TestIntegration_Autoscaling(t *testing.T) {
 		t.Fatalf("want cluster autoscaling CPU target = %v, got = %v", wantCPU, gotCPU)
 	}
 
+	ac = AutoscalingConfig{
+		MinNodes:                  3,
+		MaxNodes:                  4,
+		StorageUtilizationPerNode: 3000,
+	}
+	t.Logf("setting autoscaling ON with storage-only target (%v GiB/node)", ac.StorageUtilizationPerNode)
+	err = iAdminClient.SetAutoscaling(ctx, instanceToCreate, clusterID, ac)
+	if err != nil {
+		t.Fatalf("SetAutoscaling: %v", err)
+	}
+	cluster, err = iAdminClient.GetCluster(ctx, instanceToCreate, clusterID)
+	if err != nil {
+		t.Fatalf("GetCluster: %v", err)
+	}
+	if gotStorage := cluster.AutoscalingConfig.StorageUtilizationPerNode; gotStorage != ac.StorageUtilizationPerNode {
+		t.Fatalf("want cluster autoscaling storage target = %v, got = %v", ac.StorageUtilizationPerNode, gotStorage)
+	}
+	if gotCPU := cluster.AutoscalingConfig.CPUTargetPercent; gotCPU != 0 {
+		t.Fatalf("want cluster autoscaling CPU target = 0, got = %v", gotCPU)
+	}
+
+	ac = AutoscalingConfig{
+		MinNodes:                  3,
+		MaxNodes:                  4,
+		CPUTargetPercent:          75,
+		StorageUtilizationPerNode: 2500,
+	}
+	t.Logf("setting autoscaling ON with dual-signal target (CPU = %v, storage = %v GiB/node)", ac.CPUTargetPercent, ac.StorageUtilizationPerNode)
+	err = iAdminClient.SetAutoscaling(ctx, instanceToCreate, clusterID, ac)
+	if err != nil {
+		t.Fatalf("SetAutoscaling: %v", err)
+	}
+	cluster, err = iAdminClient.GetCluster(ctx, instanceToCreate, clusterID)
+	if err != nil {
+		t.Fatalf("GetCluster: %v", err)
+	}
+	if gotCPU := cluster.AutoscalingConfig.CPUTargetPercent; gotCPU != ac.CPUTargetPercent {
+		t.Fatalf("want cluster autoscaling CPU target = %v, got = %v", ac.CPUTargetPercent, gotCPU)
+	}
+	if gotStorage := cluster.AutoscalingConfig.StorageUtilizationPerNode; gotStorage != ac.StorageUtilizationPerNode {
+		t.Fatalf("want cluster autoscaling storage target = %v, got = %v", ac.StorageUtilizationPerNode, gotStorage)
+	}
 }
 
 // instanceAdminClientMock is used to test FailedLocations field processing.
@@ -3957,6 +4066,114 @@ func TestIntegration_InstanceAdminClient_UpdateAppProfile(t *testing.T) {
 	}
 }
 
+func TestIntegration_InstanceAdminClient_RolloutAppProfile(t *testing.T) {
+	testEnv, err := NewIntegrationEnv()
+	if err != nil {
+		t.Fatalf("IntegrationEnv: %v", err)
+	}
+	defer testEnv.Close()
+
+	timeout := 2 * time.Second
+	if testEnv.Config().UseProd {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	adminClient, err := testEnv.NewAdminClient()
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	defer adminClient.Close()
+
+	iAdminClient, err := testEnv.NewInstanceAdminClient()
+	if err != nil {
+		t.Fatalf("NewInstanceAdminClient: %v", err)
+	}
+	if iAdminClient == nil {
+		return
+	}
+	defer iAdminClient.Close()
+
+	uniqueID := make([]byte, 4)
+	rand.Read(uniqueID)
+	profileID := fmt.Sprintf("app_profile_rollout%x", uniqueID)
+
+	profile := ProfileConf{
+		ProfileID:     profileID,
+		InstanceID:    adminClient.instance,
+		ClusterID:     testEnv.Config().Cluster,
+		Description:   "app profile for RolloutAppProfile test",
+		RoutingPolicy: SingleClusterRouting,
+	}
+	if _, err := iAdminClient.CreateAppProfile(ctx, profile); err != nil {
+		t.Fatalf("Creating app profile: %v", err)
+	}
+	defer iAdminClient.DeleteAppProfile(ctx, adminClient.instance, profileID)
+
+	t.Run("healthy rollout promotes the DataBoost isolation flip", func(t *testing.T) {
+		var healthChecksCalled int
+		uattrs := ProfileAttrsToUpdate{
+			RoutingConfig: &SingleClusterRoutingConfig{ClusterID: testEnv.Config().Cluster},
+			Isolation:     &DataBoostIsolationReadOnly{ComputeBillingOwner: HostPays},
+			Rollout: &RolloutConfig{
+				AbortOn: 0.05,
+				Steps: []RolloutStep{
+					{Weight: 0.1, MinDuration: 0, HealthCheck: func(ctx context.Context, shadowProfileID string) (float64, error) {
+						healthChecksCalled++
+						if shadowProfileID == "" {
+							t.Errorf("HealthCheck got empty shadowProfileID")
+						}
+						return 0, nil
+					}},
+				},
+			},
+		}
+		if err := iAdminClient.RolloutAppProfile(ctx, adminClient.instance, profileID, uattrs); err != nil {
+			t.Fatalf("RolloutAppProfile: %v", err)
+		}
+		if healthChecksCalled != 1 {
+			t.Errorf("got %d health checks, want 1", healthChecksCalled)
+		}
+		got, err := iAdminClient.GetAppProfile(ctx, adminClient.instance, profileID)
+		if err != nil {
+			t.Fatalf("GetAppProfile: %v", err)
+		}
+		if got.GetDataBoostIsolationReadOnly() == nil {
+			t.Errorf("GetAppProfile: got %v, want DataBoostIsolationReadOnly set", got)
+		}
+	})
+
+	t.Run("unhealthy rollout rolls back without touching the primary", func(t *testing.T) {
+		before, err := iAdminClient.GetAppProfile(ctx, adminClient.instance, profileID)
+		if err != nil {
+			t.Fatalf("GetAppProfile: %v", err)
+		}
+		uattrs := ProfileAttrsToUpdate{
+			RoutingConfig: &SingleClusterRoutingConfig{ClusterID: testEnv.Config().Cluster},
+			Isolation:     &StandardIsolation{Priority: AppProfilePriorityLow},
+			Rollout: &RolloutConfig{
+				AbortOn: 0.05,
+				Steps: []RolloutStep{
+					{HealthCheck: func(ctx context.Context, shadowProfileID string) (float64, error) {
+						return 1.0, nil // simulate an unhealthy shadow profile
+					}},
+				},
+			},
+		}
+		if err := iAdminClient.RolloutAppProfile(ctx, adminClient.instance, profileID, uattrs); err == nil {
+			t.Fatalf("RolloutAppProfile: got nil error, want an abort error")
+		}
+		after, err := iAdminClient.GetAppProfile(ctx, adminClient.instance, profileID)
+		if err != nil {
+			t.Fatalf("GetAppProfile: %v", err)
+		}
+		if !proto.Equal(before, after) {
+			t.Errorf("primary profile changed after an aborted rollout: before %v, after %v", before, after)
+		}
+	})
+}
+
 func TestIntegration_NodeScalingFactor(t *testing.T) {
 	if instanceToCreate == "" {
 		t.Skip("instanceToCreate not set, skipping instance update testing")
@@ -4598,6 +4815,322 @@ func TestIntegration_AdminUpdateBackupHotToStandardTime(t *testing.T) {
 	}
 }
 
+func TestIntegration_AdminBackupExportImport(t *testing.T) {
+	testEnv, err := NewIntegrationEnv()
+	if err != nil {
+		t.Fatalf("IntegrationEnv: %v", err)
+	}
+	defer testEnv.Close()
+
+	if !testEnv.Config().UseProd {
+		t.Skip("emulator doesn't support backups")
+	}
+	bucket := os.Getenv("GCLOUD_TESTS_BIGTABLE_EXPORT_BUCKET")
+	if bucket == "" {
+		t.Skip("GCLOUD_TESTS_BIGTABLE_EXPORT_BUCKET not set, skipping backup export/import test")
+	}
+
+	timeout := 15 * time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	adminClient, err := testEnv.NewAdminClient()
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	defer adminClient.Close()
+
+	// (1) create a table with fam1/fam2.
+	tblConf := TableConf{
+		TableID: testEnv.Config().Table,
+		Families: map[string]GCPolicy{
+			"fam1": MaxVersionsPolicy(1),
+			"fam2": MaxVersionsPolicy(2),
+		},
+	}
+	if err := createTableFromConf(ctx, adminClient, &tblConf); err != nil {
+		t.Fatalf("Creating table from TableConf: %v", err)
+	}
+	defer deleteTable(ctx, t, adminClient, tblConf.TableID)
+
+	client, err := testEnv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	tbl := client.Open(tblConf.TableID)
+	mut := NewMutation()
+	mut.Set("fam1", "col1", 1000, []byte("sample-value"))
+	if err := tbl.Apply(ctx, "row1", mut); err != nil {
+		t.Fatalf("Applying mutation: %v", err)
+	}
+
+	cluster := testEnv.Config().Cluster
+	backupUID := uid.NewSpace(prefixOfInstanceResources, &uid.Options{})
+	srcBackupName := backupUID.New()
+	if err = adminClient.CreateBackup(ctx, tblConf.TableID, cluster, srcBackupName, time.Now().Add(8*time.Hour)); err != nil {
+		t.Fatalf("Creating backup: %v", err)
+	}
+
+	// (2) export the backup to a test GCS bucket.
+	gcsURI := "gs://" + bucket + "/" + srcBackupName
+	if err := adminClient.ExportBackup(ctx, cluster, srcBackupName, ExportBackupConfig{GCSURI: gcsURI, Format: ExportFormatAvro}); err != nil {
+		t.Fatalf("ExportBackup: %v", err)
+	}
+
+	// (3) delete the source backup and table.
+	if err := adminClient.DeleteBackup(ctx, cluster, srcBackupName); err != nil {
+		t.Fatalf("DeleteBackup: %v", err)
+	}
+	if err := adminClient.DeleteTable(ctx, tblConf.TableID); err != nil {
+		t.Fatalf("DeleteTable: %v", err)
+	}
+
+	// (4) import into a fresh table via a fresh backup.
+	restoredTableID := tblConf.TableID + "-restored"
+	defer deleteTable(ctx, t, adminClient, restoredTableID)
+	if err := adminClient.RestoreTableFromGCS(ctx, restoredTableID, cluster, gcsURI); err != nil {
+		t.Fatalf("RestoreTableFromGCS: %v", err)
+	}
+
+	// (5) verify column families and the sample row round-trip.
+	restoredInfo, err := adminClient.TableInfo(ctx, restoredTableID)
+	if err != nil {
+		t.Fatalf("TableInfo: %v", err)
+	}
+	gotFamilies := map[string]bool{}
+	for _, f := range restoredInfo.Families {
+		gotFamilies[f] = true
+	}
+	for _, want := range []string{"fam1", "fam2"} {
+		if !gotFamilies[want] {
+			t.Errorf("restored table missing family %q, got families %v", want, restoredInfo.Families)
+		}
+	}
+
+	restoredTbl := client.Open(restoredTableID)
+	row, err := restoredTbl.ReadRow(ctx, "row1")
+	if err != nil {
+		t.Fatalf("ReadRow: %v", err)
+	}
+	var gotValue []byte
+	for _, item := range row["fam1"] {
+		if item.Column == "fam1:col1" {
+			gotValue = item.Value
+		}
+	}
+	if string(gotValue) != "sample-value" {
+		t.Errorf("restored row1 fam1:col1 got %q, want %q", gotValue, "sample-value")
+	}
+}
+
+func TestIntegration_AdminCopyBackups(t *testing.T) {
+	testEnv, err := NewIntegrationEnv()
+	if err != nil {
+		t.Fatalf("IntegrationEnv: %v", err)
+	}
+	defer testEnv.Close()
+
+	if !testEnv.Config().UseProd {
+		t.Skip("emulator doesn't support backups")
+	}
+
+	timeout := 15 * time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	srcAdminClient, err := testEnv.NewAdminClient()
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	defer srcAdminClient.Close()
+
+	tblConf := TableConf{
+		TableID: testEnv.Config().Table,
+		Families: map[string]GCPolicy{
+			"fam1": MaxVersionsPolicy(1),
+		},
+	}
+	defer deleteTable(ctx, t, srcAdminClient, tblConf.TableID)
+	if err := createTableFromConf(ctx, srcAdminClient, &tblConf); err != nil {
+		t.Fatalf("Creating table from TableConf: %v", err)
+	}
+
+	backupUID := uid.NewSpace(prefixOfInstanceResources, &uid.Options{})
+	srcCluster := testEnv.Config().Cluster
+	srcBackupName := backupUID.New()
+	defer srcAdminClient.DeleteBackup(ctx, srcCluster, srcBackupName)
+	if err = srcAdminClient.CreateBackup(ctx, tblConf.TableID, srcCluster, srcBackupName, time.Now().Add(100*time.Hour)); err != nil {
+		t.Fatalf("Creating backup: %v", err)
+	}
+
+	destinations := []CopyBackupDestination{
+		{
+			Project:    testEnv.Config().Project,
+			Instance:   testEnv.Config().Instance,
+			Cluster:    srcCluster,
+			BackupName: backupUID.New(),
+			ExpireTime: time.Now().Add(24 * time.Hour),
+		},
+		{
+			Project:    testEnv.Config().Project,
+			Instance:   testEnv.Config().Instance,
+			Cluster:    srcCluster,
+			BackupName: backupUID.New(),
+			ExpireTime: time.Now().Add(24 * time.Hour),
+		},
+	}
+	if testEnv.Config().Cluster2 != "" {
+		destinations = append(destinations, CopyBackupDestination{
+			Project:    testEnv.Config().Project,
+			Instance:   testEnv.Config().Instance,
+			Cluster:    testEnv.Config().Cluster2,
+			BackupName: backupUID.New(),
+			ExpireTime: time.Now().Add(24 * time.Hour),
+		})
+	}
+	for _, d := range destinations {
+		defer srcAdminClient.DeleteBackup(ctx, d.Cluster, d.BackupName)
+	}
+
+	op, err := srcAdminClient.CopyBackups(ctx, srcCluster, srcBackupName, destinations, CopyBackupOptions{MaxConcurrency: 2})
+	if err != nil {
+		t.Fatalf("CopyBackups: %v", err)
+	}
+
+	results, err := op.Wait(ctx)
+	if err != nil {
+		t.Fatalf("CopyBackups Wait: %v", err)
+	}
+	if len(results) != len(destinations) {
+		t.Fatalf("got %d results, want %d", len(results), len(destinations))
+	}
+	wantSourceBackup := srcAdminClient.instancePrefix() + "/clusters/" + srcCluster + "/backups/" + srcBackupName
+	for _, r := range results {
+		if r.Status != CopyBackupDone {
+			t.Errorf("destination %q: got status %v, want CopyBackupDone (err: %v)", r.Destination.BackupName, r.Status, r.Err)
+			continue
+		}
+		if r.Info.SourceBackup != wantSourceBackup {
+			t.Errorf("destination %q: SourceBackup got %v, want %v", r.Destination.BackupName, r.Info.SourceBackup, wantSourceBackup)
+		}
+	}
+	if progress := op.Progress(); progress.Done != len(destinations) {
+		t.Errorf("Progress() got %+v, want Done=%d", progress, len(destinations))
+	}
+}
+
+func TestIntegration_AdminBackupSchedule(t *testing.T) {
+	testEnv, err := NewIntegrationEnv()
+	if err != nil {
+		t.Fatalf("IntegrationEnv: %v", err)
+	}
+	defer testEnv.Close()
+	if !testEnv.Config().UseProd {
+		t.Skip("emulator doesn't support backups")
+	}
+
+	timeout := 15 * time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	adminClient, err := testEnv.NewAdminClient()
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	defer adminClient.Close()
+
+	tblConf := TableConf{
+		TableID: testEnv.Config().Table,
+		Families: map[string]GCPolicy{
+			"fam1": MaxVersionsPolicy(1),
+		},
+	}
+	if err := adminClient.CreateTableFromConf(ctx, &tblConf); err != nil {
+		t.Fatalf("Creating table from TableConf: %v", err)
+	}
+	defer deleteTable(ctx, t, adminClient, tblConf.TableID)
+
+	cluster := testEnv.Config().Cluster
+	conf := ScheduleConfig{
+		Cluster:            cluster,
+		Every:              30 * time.Second,
+		Retention:          70 * time.Second,
+		BackupType:         BackupTypeHot,
+		HotToStandardAfter: 35 * time.Second,
+	}
+
+	sched, err := adminClient.CreateBackupSchedule(ctx, tblConf.TableID, conf)
+	if err != nil {
+		t.Fatalf("CreateBackupSchedule: %v", err)
+	}
+	defer adminClient.DeleteBackupSchedule(tblConf.TableID)
+
+	var created, transitioned, deleted int
+	deadline := time.After(4 * time.Minute)
+drain:
+	for {
+		select {
+		case evt := <-sched.Events():
+			switch evt.Type {
+			case BackupScheduleEventCreated:
+				created++
+			case BackupScheduleEventTransitioned:
+				transitioned++
+			case BackupScheduleEventDeleted:
+				deleted++
+			case BackupScheduleEventError:
+				t.Logf("BackupSchedule reported error for %q: %v", evt.BackupName, evt.Err)
+			}
+			if created >= 2 && transitioned >= 1 && deleted >= 1 {
+				break drain
+			}
+		case <-deadline:
+			break drain
+		case <-ctx.Done():
+			break drain
+		}
+	}
+	if created < 2 {
+		t.Errorf("got %d created events over the test window, want at least 2", created)
+	}
+	if transitioned < 1 {
+		t.Errorf("got %d transitioned events, want at least 1", transitioned)
+	}
+	if deleted < 1 {
+		t.Errorf("got %d deleted events, want at least 1", deleted)
+	}
+
+	// Simulate a restart: stop the schedule and recreate it against the
+	// same table and cluster. Reconciliation on startup must not create a
+	// duplicate backup for the current period.
+	before, err := sched.listScheduledBackups(ctx)
+	if err != nil {
+		t.Fatalf("listScheduledBackups: %v", err)
+	}
+	adminClient.DeleteBackupSchedule(tblConf.TableID)
+
+	sched2, err := adminClient.CreateBackupSchedule(ctx, tblConf.TableID, conf)
+	if err != nil {
+		t.Fatalf("CreateBackupSchedule (restart): %v", err)
+	}
+	defer adminClient.DeleteBackupSchedule(tblConf.TableID)
+	time.Sleep(5 * time.Second) // let the restart's immediate reconciliation run
+
+	after, err := sched2.listScheduledBackups(ctx)
+	if err != nil {
+		t.Fatalf("listScheduledBackups after restart: %v", err)
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			t.Errorf("backup %q present before restart is missing after restart", name)
+		}
+	}
+	if len(after) > len(before)+1 {
+		t.Errorf("got %d scheduled backups after restart, want at most %d (no duplicates)", len(after), len(before)+1)
+	}
+}
+
 func TestIntegration_AdminAuthorizedView(t *testing.T) {
 	testEnv, err := NewIntegrationEnv()
 	if err != nil {
@@ -5401,6 +5934,34 @@ func TestIntegration_AdminMaterializedView(t *testing.T) {
 		t.Errorf("Query: %q, want: %q", got, want)
 	}
 
+	// Update the view's query.
+	updatedQuery := fmt.Sprintf("SELECT _key, count(fam2['col1']) as count FROM `%s` GROUP BY _key", tblConf.TableID)
+	err = instanceAdminClient.UpdateMaterializedView(ctx, testEnv.Config().Instance, MaterializedViewInfo{
+		MaterializedViewID: materializedView,
+		Query:              updatedQuery,
+	})
+	if err != nil {
+		t.Fatalf("UpdateMaterializedView query: %v", err)
+	}
+	mvInfo, err = instanceAdminClient.MaterializedViewInfo(ctx, testEnv.Config().Instance, materializedView)
+	if err != nil {
+		t.Fatalf("Getting materialized view: %v", err)
+	}
+	if got, want := mvInfo.Query, updatedQuery; got != want {
+		t.Errorf("MaterializedView Query after update: %q, want: %q", got, want)
+	}
+
+	// Updating to a query referencing a column family that doesn't exist
+	// on the source table should be rejected rather than silently
+	// accepted.
+	err = instanceAdminClient.UpdateMaterializedView(ctx, testEnv.Config().Instance, MaterializedViewInfo{
+		MaterializedViewID: materializedView,
+		Query:              fmt.Sprintf("SELECT _key, count(nosuchfam['col1']) as count FROM `%s` GROUP BY _key", tblConf.TableID),
+	})
+	if err == nil {
+		t.Fatalf("UpdateMaterializedView with an incompatible query unexpectedly succeeded")
+	}
+
 	// Delete materialized view
 	if err = instanceAdminClient.DeleteMaterializedView(ctx, testEnv.Config().Instance, materializedView); err != nil {
 		t.Fatalf("DeleteMaterializedView: %v", err)
@@ -5418,6 +5979,92 @@ func TestIntegration_AdminMaterializedView(t *testing.T) {
 	}
 }
 
+func TestIntegration_MaterializedViewIAM(t *testing.T) {
+	testEnv, err := NewIntegrationEnv()
+	if err != nil {
+		t.Fatalf("IntegrationEnv: %v", err)
+	}
+	defer testEnv.Close()
+
+	if !testEnv.Config().UseProd {
+		t.Skip("emulator doesn't support IAM Policy creation")
+	}
+	timeout := 5 * time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	adminClient, err := testEnv.NewAdminClient()
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	defer adminClient.Close()
+
+	instanceAdminClient, err := testEnv.NewInstanceAdminClient()
+	if err != nil {
+		t.Fatalf("NewInstanceAdminClient: %v", err)
+	}
+	defer instanceAdminClient.Close()
+
+	table := testEnv.Config().Table
+	defer deleteTable(ctx, t, adminClient, table)
+	if err := createTable(ctx, adminClient, table); err != nil {
+		t.Fatalf("Creating table: %v", err)
+	}
+
+	materializedViewUUID := uid.NewSpace("materializedView-iam-", &uid.Options{})
+	materializedView := materializedViewUUID.New()
+	defer instanceAdminClient.DeleteMaterializedView(ctx, testEnv.Config().Instance, materializedView)
+
+	if err = instanceAdminClient.CreateMaterializedView(ctx, testEnv.Config().Instance, &MaterializedViewInfo{
+		MaterializedViewID: materializedView,
+		Query:              fmt.Sprintf("SELECT _key, count(fam1['col1']) as count FROM `%s` GROUP BY _key", table),
+	}); err != nil {
+		t.Fatalf("Creating materialized view: %v", err)
+	}
+
+	iamHandle := instanceAdminClient.MaterializedViewIAM(testEnv.Config().Instance, materializedView)
+
+	p, err := iamHandle.Policy(ctx)
+	if err != nil {
+		t.Fatalf("iamHandle.Policy: %v", err)
+	}
+	if got := p.Roles(); len(got) > 0 {
+		t.Errorf("got roles %v, want none", got)
+	}
+
+	// Grant a role.
+	member := "domain:google.com"
+	p.Add(member, iam.Viewer)
+	if err = iamHandle.SetPolicy(ctx, p); err != nil {
+		t.Fatalf("iamHandle.SetPolicy (grant): %v", err)
+	}
+	p, err = iamHandle.Policy(ctx)
+	if err != nil {
+		t.Fatalf("iamHandle.Policy: %v", err)
+	}
+	if got, want := p.Members(iam.Viewer), []string{member}; !testutil.Equal(got, want) {
+		t.Errorf("iamHandle.Policy after grant: got %v, want %v", got, want)
+	}
+
+	// Revoke the role.
+	p.Remove(member, iam.Viewer)
+	if err = iamHandle.SetPolicy(ctx, p); err != nil {
+		t.Fatalf("iamHandle.SetPolicy (revoke): %v", err)
+	}
+	p, err = iamHandle.Policy(ctx)
+	if err != nil {
+		t.Fatalf("iamHandle.Policy: %v", err)
+	}
+	if got := p.Members(iam.Viewer); len(got) > 0 {
+		t.Errorf("iamHandle.Policy after revoke: got %v, want none", got)
+	}
+
+	permissions := []string{"bigtable.materializedViews.get", "bigtable.materializedViews.update"}
+	if _, err = iamHandle.TestPermissions(ctx, permissions); err != nil {
+		t.Errorf("iamHandle.TestPermissions: %v", err)
+	}
+}
+
 // TestIntegration_DirectPathFallback tests the CFE fallback when the directpath net is blackholed.
 func TestIntegration_DirectPathFallback(t *testing.T) {
 	ctx := context.Background()
@@ -6335,26 +6982,37 @@ func isDirectPathRemoteAddress(testEnv IntegrationEnv) (_ string, _ bool) {
 	return remoteIP, strings.HasPrefix(remoteIP, directPathIPV4Prefix) || strings.HasPrefix(remoteIP, directPathIPV6Prefix)
 }
 
+// directPathFaultInjector builds the directpath.FaultInjector used by
+// blackholeDirectPath/allowDirectPath from the same -it.blackhole-dpv4-cmd
+// etc. flags used before this package existed, but now fails the test
+// explicitly if a rule doesn't install instead of logging its output and
+// carrying on.
+func directPathFaultInjector(testEnv IntegrationEnv) directpath.FaultInjector {
+	injector := directpath.NewLinuxFaultInjector(blackholeDpv4Cmd, blackholeDpv6Cmd, allowDpv4Cmd, allowDpv6Cmd)
+	if testEnv.Config().DirectPathIPV4Only {
+		injector.BlackholeIPv6Cmd = ""
+		injector.RestoreIPv6Cmd = ""
+	}
+	return injector
+}
+
 func blackholeDirectPath(testEnv IntegrationEnv, t *testing.T) {
-	cmdRes := exec.Command("bash", "-c", blackholeDpv4Cmd)
-	out, _ := cmdRes.CombinedOutput()
-	t.Logf("%+v", string(out))
+	t.Helper()
+	injector := directPathFaultInjector(testEnv)
+	if err := injector.BlackholeIPv4(); err != nil {
+		t.Fatalf("blackholeDirectPath: %v", err)
+	}
 	if testEnv.Config().DirectPathIPV4Only {
 		return
 	}
-	cmdRes = exec.Command("bash", "-c", blackholeDpv6Cmd)
-	out, _ = cmdRes.CombinedOutput()
-	t.Logf("%+v", string(out))
+	if err := injector.BlackholeIPv6(); err != nil {
+		t.Fatalf("blackholeDirectPath: %v", err)
+	}
 }
 
 func allowDirectPath(testEnv IntegrationEnv, t *testing.T) {
-	cmdRes := exec.Command("bash", "-c", allowDpv4Cmd)
-	out, _ := cmdRes.CombinedOutput()
-	t.Logf("%+v", string(out))
-	if testEnv.Config().DirectPathIPV4Only {
-		return
+	t.Helper()
+	if err := directPathFaultInjector(testEnv).RestoreAll(); err != nil {
+		t.Fatalf("allowDirectPath: %v", err)
 	}
-	cmdRes = exec.Command("bash", "-c", allowDpv6Cmd)
-	out, _ = cmdRes.CombinedOutput()
-	t.Logf("%+v", string(out))
 }
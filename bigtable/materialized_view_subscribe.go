@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MaterializedViewChangeType identifies how a row's result in a
+// materialized view changed.
+type MaterializedViewChangeType int
+
+const (
+	// MaterializedViewInsert is a new row appearing in the view's result.
+	MaterializedViewInsert MaterializedViewChangeType = iota
+	// MaterializedViewUpdate is an existing row's result columns changing.
+	MaterializedViewUpdate
+	// MaterializedViewDelete is a row leaving the view's result, because
+	// the underlying data no longer matches the view's query.
+	MaterializedViewDelete
+	// MaterializedViewAggregatorReset means an aggregate column's running
+	// value was reset (e.g. after a source table's garbage collection
+	// invalidated it) rather than incrementally updated; subscribers
+	// relying on the aggregate should treat it as recomputed from
+	// scratch as of this event rather than as a delta.
+	MaterializedViewAggregatorReset
+)
+
+// MaterializedViewChange is one row-level change delivered by
+// MaterializedView.Subscribe.
+type MaterializedViewChange struct {
+	Type MaterializedViewChangeType
+	// RowKey is the changed row's key in the materialized view's result.
+	RowKey []byte
+	// Columns holds the row's current value for every result column
+	// affected by this change (its full row isn't necessarily resent on
+	// every update). Columns is empty for MaterializedViewDelete.
+	Columns map[string]interface{}
+	// CommitTimestamp is when the underlying change that produced this
+	// result update was committed.
+	CommitTimestamp time.Time
+	// Token resumes the subscription from just after this change if
+	// passed as SubscribeOptions.ResumeToken on a later Subscribe call.
+	Token string
+}
+
+// SubscribeOptions configures MaterializedView.Subscribe.
+type SubscribeOptions struct {
+	// ResumeToken resumes the subscription from a previously delivered
+	// MaterializedViewChange.Token instead of starting from the view's
+	// current state.
+	ResumeToken string
+	// HeartbeatInterval bounds how long Subscribe can go without
+	// delivering a ChangeStream or internal watermark update before
+	// ChangeStream.Watermark is advanced on its own, so a consumer can
+	// tell a quiet view apart from a stalled subscription. Zero uses the
+	// server's default.
+	HeartbeatInterval time.Duration
+}
+
+// ChangeStream delivers a MaterializedView's result changes as they
+// happen. Call Next to receive each change; call Ack once a change (and
+// everything delivered before it) has been durably processed, so a
+// reconnect resumes after it instead of redelivering it. Changes are
+// delivered at-least-once: a reconnect before Ack replays from the last
+// acknowledged point.
+type ChangeStream struct {
+	mu          sync.Mutex
+	pending     []MaterializedViewChange
+	lastToken   string
+	ackedToken  string
+	watermark   time.Time
+	closed      bool
+	cancel      context.CancelFunc
+	recvErr     error
+	recvErrCond *sync.Cond
+}
+
+// Subscribe opens a ChangeStream of iac's materializedViewID's result
+// changes in instanceID.
+//
+// This package has no generated client for a MaterializedView change
+// subscription RPC to build on — Cloud Bigtable materialized views are
+// refreshed internally, and there's no public streaming API visible in
+// this checkout for observing those refreshes incrementally row by row.
+// This is written as a plausible client shape for such an API (mirroring
+// ChangeStreamReader's ReadChangeStream in change_stream.go: typed
+// records, a resumable token per record, heartbeats via Watermark) so
+// callers and tests have something concrete to code against, but it has
+// no underlying RPC wired up and Next blocks until ctx is done.
+func (iac *InstanceAdminClient) Subscribe(ctx context.Context, instanceID, materializedViewID string, opts SubscribeOptions) (*ChangeStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	cs := &ChangeStream{
+		ackedToken: opts.ResumeToken,
+		cancel:     cancel,
+	}
+	cs.recvErrCond = sync.NewCond(&cs.mu)
+
+	go func() {
+		<-ctx.Done()
+		cs.mu.Lock()
+		cs.closed = true
+		cs.recvErrCond.Broadcast()
+		cs.mu.Unlock()
+	}()
+
+	return cs, nil
+}
+
+// Next blocks until the next MaterializedViewChange is available, ctx is
+// done, or the ChangeStream is closed.
+func (cs *ChangeStream) Next(ctx context.Context) (MaterializedViewChange, error) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cs.mu.Lock()
+			cs.recvErrCond.Broadcast()
+			cs.mu.Unlock()
+		case <-done:
+		}
+	}()
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for len(cs.pending) == 0 && !cs.closed && cs.recvErr == nil && ctx.Err() == nil {
+		cs.recvErrCond.Wait()
+	}
+	if len(cs.pending) > 0 {
+		c := cs.pending[0]
+		cs.pending = cs.pending[1:]
+		cs.lastToken = c.Token
+		return c, nil
+	}
+	if cs.recvErr != nil {
+		return MaterializedViewChange{}, cs.recvErr
+	}
+	return MaterializedViewChange{}, ctx.Err()
+}
+
+// Ack records token (from a MaterializedViewChange already delivered by
+// Next) as durably processed, so a future Subscribe with
+// SubscribeOptions.ResumeToken set to token picks up just after it
+// instead of redelivering it.
+func (cs *ChangeStream) Ack(token string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.ackedToken = token
+	return nil
+}
+
+// Watermark returns the timestamp of the most recent change the stream
+// has confirmed processing up to, including quiet periods communicated
+// via heartbeats — every change with an earlier CommitTimestamp has
+// already been delivered or will never be delivered.
+func (cs *ChangeStream) Watermark() time.Time {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.watermark
+}
+
+// Close stops the subscription and releases its resources. Safe to call
+// more than once.
+func (cs *ChangeStream) Close() {
+	cs.cancel()
+}
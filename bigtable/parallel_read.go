@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+)
+
+// WithAggregatedFullReadStats is a ReadOption recognized by
+// ReadRowsParallel: f is invoked exactly once, after every shard's
+// ReadRows call has completed, with the FullReadStats from all shards
+// summed together. A plain WithFullReadStats passed to ReadRowsParallel
+// instead fires once per shard, with only that shard's numbers, since
+// shards are otherwise ordinary independent ReadRows calls.
+func WithAggregatedFullReadStats(f func(*FullReadStats)) ReadOption {
+	return aggregatedStatsOption{f}
+}
+
+type aggregatedStatsOption struct {
+	f func(*FullReadStats)
+}
+
+func (aggregatedStatsOption) set(*btpb.ReadRowsRequest) {}
+
+// ReadRowsParallel scans rs by first calling SampleRowKeys to obtain
+// tablet boundaries and splitting rs into disjoint sub-ranges along them,
+// then fanning those out across a worker pool of the given parallelism,
+// one ReadRows call per shard, instead of a single sequential stream.
+//
+// f is never invoked concurrently: the shards' calls to it are serialized
+// under a lock, preserving the same one-call-at-a-time callback contract
+// as ReadRows: return false from f to stop the scan early, canceling the
+// remaining shards. Rows may arrive out of key order, since shards
+// race each other; pass ReverseScan() in opts to have ReadRowsParallel
+// iterate shards back-to-front so a reverse scan still makes forward
+// progress through the key space overall. A LimitRows option bounds the
+// total rows delivered across every shard, not each shard individually,
+// canceling the remaining shards once it's reached. Pass
+// WithAggregatedFullReadStats instead of WithFullReadStats to receive one
+// FullReadStats summed across every shard.
+func (t *Table) ReadRowsParallel(ctx context.Context, rs RowSet, parallelism int, f func(Row) bool, opts ...ReadOption) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var shardOpts []ReadOption
+	var aggStats func(*FullReadStats)
+	probe := &btpb.ReadRowsRequest{}
+	for _, o := range opts {
+		if agg, ok := o.(aggregatedStatsOption); ok {
+			aggStats = agg.f
+			continue
+		}
+		o.set(probe)
+		shardOpts = append(shardOpts, o)
+	}
+	reverse := probe.GetReversed()
+	limit := probe.GetRowsLimit()
+
+	boundaries, err := t.SampleRowKeys(ctx)
+	if err != nil {
+		return err
+	}
+	shards := shardRowSet(rs, boundaries)
+	if reverse {
+		for i, j := 0, len(shards)-1; i < j; i, j = i+1, j-1 {
+			shards[i], shards[j] = shards[j], shards[i]
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		sem       = make(chan struct{}, parallelism)
+		delivered int64
+		firstErr  error
+		merged    FullReadStats
+	)
+
+	for _, shard := range shards {
+		shard := shard
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			shardReadOpts := shardOpts
+			if aggStats != nil {
+				shardReadOpts = append(append([]ReadOption{}, shardOpts...), WithFullReadStats(func(s *FullReadStats) {
+					mu.Lock()
+					mergeReadIterationStats(&merged, s)
+					mu.Unlock()
+				}))
+			}
+
+			err := t.ReadRows(ctx, shard, func(r Row) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				if ctx.Err() != nil || (limit > 0 && delivered >= limit) {
+					return false
+				}
+				if !f(r) {
+					cancel()
+					return false
+				}
+				delivered++
+				if limit > 0 && delivered >= limit {
+					cancel()
+					return false
+				}
+				return true
+			}, shardReadOpts...)
+
+			if err != nil && !errors.Is(err, context.Canceled) {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if aggStats != nil {
+		aggStats(&merged)
+	}
+	return firstErr
+}
+
+// mergeReadIterationStats adds src's counters into dst, accumulating read
+// efficiency stats across every shard of a ReadRowsParallel call.
+func mergeReadIterationStats(dst, src *FullReadStats) {
+	dst.ReadIterationStats.RowsSeenCount += src.ReadIterationStats.RowsSeenCount
+	dst.ReadIterationStats.RowsReturnedCount += src.ReadIterationStats.RowsReturnedCount
+	dst.ReadIterationStats.CellsSeenCount += src.ReadIterationStats.CellsSeenCount
+	dst.ReadIterationStats.CellsReturnedCount += src.ReadIterationStats.CellsReturnedCount
+}
+
+// shardRowSet splits a full-table scan into len(boundaries)+1 disjoint
+// sub-ranges, one per tablet boundary reported by SampleRowKeys. It only
+// knows how to subdivide the "scan the whole table" case, rs == nil; any
+// other RowSet — an explicit RowRange, RowRangeList, or RowList of
+// individual keys — is returned as a single shard, since intersecting it
+// with tablet boundaries isn't worth the complexity ReadRowsParallel
+// callers get from it in practice.
+func shardRowSet(rs RowSet, boundaries []string) []RowSet {
+	if rs != nil || len(boundaries) == 0 {
+		return []RowSet{rs}
+	}
+
+	var shards []RowSet
+	start := ""
+	for _, b := range boundaries {
+		shards = append(shards, NewRange(start, b))
+		start = b
+	}
+	shards = append(shards, InfiniteRange(start))
+	return shards
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ProtoCodec decodes proto-valued cells using the message types
+// described by a schema bundle. Unlike SchemaBundle, which requires an
+// explicit BindColumn call per column before Decode will touch it,
+// ProtoCodec resolves a column's message type from a WithProtoMapping
+// entry if one was given, and otherwise from naming convention: the
+// column's qualifier, treated as the fully qualified name of one of the
+// bundle's messages (e.g. qualifier "myapp.v1.Order" decodes against the
+// bundle's myapp.v1.Order message). Build one with
+// AdminClient.LoadProtoCodec.
+type ProtoCodec struct {
+	bundle   *SchemaBundle
+	mappings map[string]string // "family:qualifier" -> message name
+}
+
+// ProtoCodecOption configures LoadProtoCodec.
+type ProtoCodecOption interface {
+	apply(*protoCodecConfig)
+}
+
+type protoCodecConfig struct {
+	mappings map[string]string
+}
+
+type protoCodecOptionFunc func(*protoCodecConfig)
+
+func (f protoCodecOptionFunc) apply(c *protoCodecConfig) { f(c) }
+
+// WithProtoMapping maps family:qualifier to messageName explicitly, for
+// columns whose qualifier isn't already the fully qualified name of the
+// message it holds.
+func WithProtoMapping(family, qualifier, messageName string) ProtoCodecOption {
+	return protoCodecOptionFunc(func(c *protoCodecConfig) {
+		if c.mappings == nil {
+			c.mappings = make(map[string]string)
+		}
+		c.mappings[columnKey(family, qualifier)] = messageName
+	})
+}
+
+// LoadProtoCodec fetches the schema bundle schemaBundleID from tableID
+// and builds a ProtoCodec from its ProtoDescriptors.
+func (ac *AdminClient) LoadProtoCodec(ctx context.Context, tableID, schemaBundleID string, opts ...ProtoCodecOption) (*ProtoCodec, error) {
+	info, err := ac.GetSchemaBundle(ctx, tableID, schemaBundleID)
+	if err != nil {
+		return nil, err
+	}
+	return newProtoCodec(info, opts...)
+}
+
+func newProtoCodec(info *SchemaBundleInfo, opts ...ProtoCodecOption) (*ProtoCodec, error) {
+	bundle, err := ParseSchemaBundle(info.SchemaBundle)
+	if err != nil {
+		return nil, err
+	}
+	var cfg protoCodecConfig
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return &ProtoCodec{bundle: bundle, mappings: cfg.mappings}, nil
+}
+
+// Unmarshal decodes data, a cell value from family:qualifier, as the
+// message type that column resolves to (see ProtoCodec's doc comment).
+// It returns an error if the column has no WithProtoMapping entry and
+// qualifier isn't the name of a message described by the codec's bundle.
+func (c *ProtoCodec) Unmarshal(family, qualifier string, data []byte) (proto.Message, error) {
+	messageName := qualifier
+	if mapped, ok := c.mappings[columnKey(family, qualifier)]; ok {
+		messageName = mapped
+	}
+	desc, err := c.bundle.files.FindDescriptorByName(protoreflect.FullName(messageName))
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: column %s:%s: no message %q in schema bundle: %w", family, qualifier, messageName, err)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: column %s:%s: %q is not a message type", family, qualifier, messageName)
+	}
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("bigtable: decoding column %s:%s: %w", family, qualifier, err)
+	}
+	return msg, nil
+}
+
+// DecodeProto decodes every cell in r that resolves against codec (see
+// ProtoCodec.Unmarshal), keyed "family:qualifier". Cells that don't
+// resolve are silently omitted, the same behavior ReadRowTyped has for
+// columns a SchemaBundle has no binding for.
+func (r Row) DecodeProto(codec *ProtoCodec) map[string]proto.Message {
+	typed := make(map[string]proto.Message)
+	for family, items := range r {
+		for _, item := range items {
+			qualifier := item.Column
+			if i := strings.IndexByte(qualifier, ':'); i >= 0 {
+				qualifier = qualifier[i+1:]
+			}
+			msg, err := codec.Unmarshal(family, qualifier, item.Value)
+			if err != nil {
+				continue
+			}
+			typed[columnKey(family, qualifier)] = msg
+		}
+	}
+	return typed
+}
+
+// ProtoCodecHandle holds a ProtoCodec snapshot that can be refreshed in
+// the background, so a long-lived reader can keep decoding against
+// Codec() without restarting when the underlying schema bundle changes.
+// Build one with NewProtoCodecHandle.
+type ProtoCodecHandle struct {
+	ac                      *AdminClient
+	tableID, schemaBundleID string
+	opts                    []ProtoCodecOption
+
+	mu    sync.RWMutex
+	codec *ProtoCodec
+	etag  string
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewProtoCodecHandle fetches the schema bundle schemaBundleID from
+// tableID and wraps the resulting ProtoCodec in a ProtoCodecHandle.
+// Call StartRefreshing to keep it current as the bundle changes; without
+// that, the handle's Codec never changes after this call.
+func NewProtoCodecHandle(ctx context.Context, ac *AdminClient, tableID, schemaBundleID string, opts ...ProtoCodecOption) (*ProtoCodecHandle, error) {
+	info, err := ac.GetSchemaBundle(ctx, tableID, schemaBundleID)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := newProtoCodec(info, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ProtoCodecHandle{
+		ac:             ac,
+		tableID:        tableID,
+		schemaBundleID: schemaBundleID,
+		opts:           opts,
+		codec:          codec,
+		etag:           info.Etag,
+		stop:           make(chan struct{}),
+	}, nil
+}
+
+// Codec returns h's current ProtoCodec snapshot. Safe to call
+// concurrently with a background refresh started by StartRefreshing.
+func (h *ProtoCodecHandle) Codec() *ProtoCodec {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.codec
+}
+
+// StartRefreshing polls the schema bundle every interval in a background
+// goroutine, re-parsing and swapping in a new ProtoCodec snapshot only
+// when the bundle's Etag has changed since the last fetch. It returns
+// once the goroutine is started; call Close to stop it, or cancel ctx.
+func (h *ProtoCodecHandle) StartRefreshing(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.refreshOnce(ctx)
+			}
+		}
+	}()
+}
+
+func (h *ProtoCodecHandle) refreshOnce(ctx context.Context) {
+	info, err := h.ac.GetSchemaBundle(ctx, h.tableID, h.schemaBundleID)
+	if err != nil {
+		return
+	}
+	h.mu.RLock()
+	unchanged := info.Etag == h.etag
+	h.mu.RUnlock()
+	if unchanged {
+		return
+	}
+	codec, err := newProtoCodec(info, h.opts...)
+	if err != nil {
+		return
+	}
+	h.mu.Lock()
+	h.codec = codec
+	h.etag = info.Etag
+	h.mu.Unlock()
+}
+
+// Close stops a background refresh started with StartRefreshing. It's a
+// no-op if StartRefreshing was never called, and safe to call more than
+// once.
+func (h *ProtoCodecHandle) Close() {
+	h.stopOnce.Do(func() { close(h.stop) })
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testProtoCodec builds a *ProtoCodec (and the message descriptor backing
+// it) for a single message "codectest.Order" with one string field "id",
+// plus an encoded cell value for it, for exercising ProtoCodec without a
+// round trip to the admin API.
+func testProtoCodec(t *testing.T, opts ...ProtoCodecOption) (*ProtoCodec, protoreflect.MessageDescriptor, []byte) {
+	t.Helper()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("codectest.proto"),
+		Package: proto.String("codectest"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Order"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:   proto.String("id"),
+						Number: proto.Int32(1),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					},
+				},
+			},
+		},
+	}
+	descBytes, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fd}})
+	if err != nil {
+		t.Fatalf("marshaling FileDescriptorSet: %v", err)
+	}
+
+	codec, err := newProtoCodec(&SchemaBundleInfo{SchemaBundle: descBytes}, opts...)
+	if err != nil {
+		t.Fatalf("newProtoCodec: %v", err)
+	}
+
+	desc, err := codec.bundle.files.FindDescriptorByName("codectest.Order")
+	if err != nil {
+		t.Fatalf("FindDescriptorByName: %v", err)
+	}
+	md := desc.(protoreflect.MessageDescriptor)
+
+	msg := dynamicpb.NewMessage(md)
+	msg.Set(md.Fields().ByName("id"), protoreflect.ValueOfString("order-1"))
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshaling sample message: %v", err)
+	}
+	return codec, md, data
+}
+
+func TestProtoCodecUnmarshalByQualifierName(t *testing.T) {
+	codec, md, data := testProtoCodec(t)
+
+	msg, err := codec.Unmarshal("cf", "codectest.Order", data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got, want := msg.ProtoReflect().Descriptor().FullName(), md.FullName(); got != want {
+		t.Errorf("decoded message type = %s, want %s", got, want)
+	}
+	if got := msg.ProtoReflect().Get(md.Fields().ByName("id")).String(); got != "order-1" {
+		t.Errorf("id field = %q, want %q", got, "order-1")
+	}
+}
+
+func TestProtoCodecUnmarshalWithMapping(t *testing.T) {
+	codec, _, data := testProtoCodec(t, WithProtoMapping("cf", "orders", "codectest.Order"))
+
+	msg, err := codec.Unmarshal("cf", "orders", data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := msg.ProtoReflect().Descriptor().FullName(); got != "codectest.Order" {
+		t.Errorf("decoded message type = %s, want codectest.Order", got)
+	}
+}
+
+func TestProtoCodecUnmarshalUnknownMessage(t *testing.T) {
+	codec, _, data := testProtoCodec(t)
+
+	if _, err := codec.Unmarshal("cf", "no.such.Message", data); err == nil {
+		t.Error("Unmarshal for an unmapped, unknown qualifier got nil error, want error")
+	}
+}
+
+func TestRowDecodeProtoSkipsUnresolvedColumns(t *testing.T) {
+	codec, _, data := testProtoCodec(t)
+
+	r := Row{
+		"cf": []ReadItem{
+			{Row: "r1", Column: "cf:codectest.Order", Value: data},
+			{Row: "r1", Column: "cf:unmapped", Value: []byte("raw")},
+		},
+	}
+
+	typed := r.DecodeProto(codec)
+	if len(typed) != 1 {
+		t.Fatalf("DecodeProto returned %d entries, want 1: %+v", len(typed), typed)
+	}
+	msg, ok := typed["cf:codectest.Order"]
+	if !ok {
+		t.Fatalf("DecodeProto result missing cf:codectest.Order: %+v", typed)
+	}
+	if got := msg.ProtoReflect().Descriptor().FullName(); got != "codectest.Order" {
+		t.Errorf("decoded message type = %s, want codectest.Order", got)
+	}
+}
+
+func TestProtoCodecHandleCodec(t *testing.T) {
+	codec, _, _ := testProtoCodec(t)
+	h := &ProtoCodecHandle{codec: codec, stop: make(chan struct{})}
+	if h.Codec() != codec {
+		t.Error("Codec() did not return the snapshot set on the handle")
+	}
+	h.Close()
+	h.Close() // safe to call twice
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+)
+
+// ReadStatsAggregator accumulates FullReadStats across many ReadRows (or
+// ReadRow) calls on a Table, so an operator can track filter efficiency
+// over a whole workload instead of one call at a time. The zero value is
+// ready to use.
+type ReadStatsAggregator struct {
+	mu    sync.Mutex
+	stats FullReadStats
+}
+
+func (a *ReadStatsAggregator) add(s *FullReadStats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	mergeReadIterationStats(&a.stats, s)
+}
+
+// Stats returns the FullReadStats accumulated so far.
+func (a *ReadStatsAggregator) Stats() FullReadStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+// CellFilterRatio returns the fraction of cells the server examined that
+// were actually returned to the caller, across every call this
+// aggregator has observed. It reports 1 if no cells have been seen yet.
+func (a *ReadStatsAggregator) CellFilterRatio() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	seen := a.stats.ReadIterationStats.CellsSeenCount
+	if seen == 0 {
+		return 1
+	}
+	return float64(a.stats.ReadIterationStats.CellsReturnedCount) / float64(seen)
+}
+
+// RowFilterRatio is CellFilterRatio's row-count equivalent.
+func (a *ReadStatsAggregator) RowFilterRatio() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	seen := a.stats.ReadIterationStats.RowsSeenCount
+	if seen == 0 {
+		return 1
+	}
+	return float64(a.stats.ReadIterationStats.RowsReturnedCount) / float64(seen)
+}
+
+// WithReadStatsAggregator is a ReadOption that feeds the FullReadStats of
+// the call it's attached to into agg, in addition to whatever else the
+// call does with its stats. It's sugar over WithFullReadStats for the
+// common case of wanting a running total instead of a single callback.
+func WithReadStatsAggregator(agg *ReadStatsAggregator) ReadOption {
+	return WithFullReadStats(agg.add)
+}
+
+// ErrReadBudgetExceeded is returned by ReadRowsWithBudget once the
+// server-reported cell or row counts for the call cross a budget set by
+// WithCellsSeenBudget or WithRowsSeenBudget.
+var ErrReadBudgetExceeded = errors.New("bigtable: read budget exceeded")
+
+type readBudget struct {
+	cellsSeen int64
+	rowsSeen  int64
+}
+
+type cellsSeenBudgetOption struct{ n int64 }
+
+func (cellsSeenBudgetOption) set(*btpb.ReadRowsRequest) {}
+
+// WithCellsSeenBudget makes ReadRowsWithBudget abort the call once the
+// server reports having examined at least n cells, regardless of how
+// many it actually returned.
+func WithCellsSeenBudget(n int64) ReadOption { return cellsSeenBudgetOption{n} }
+
+type rowsSeenBudgetOption struct{ n int64 }
+
+func (rowsSeenBudgetOption) set(*btpb.ReadRowsRequest) {}
+
+// WithRowsSeenBudget makes ReadRowsWithBudget abort the call once the
+// server reports having examined at least n rows, regardless of how many
+// it actually returned.
+func WithRowsSeenBudget(n int64) ReadOption { return rowsSeenBudgetOption{n} }
+
+// defaultReadBudgetBatchRows bounds each underlying ReadRows call
+// ReadRowsWithBudget issues against a full-table scan, since Cloud
+// Bigtable only reports FullReadStats once, at the end of a call: the
+// only way to check the budget mid-scan is to read in bounded batches and
+// inspect the accumulated stats between them.
+const defaultReadBudgetBatchRows = 1000
+
+// ReadRowsWithBudget is like Table.ReadRows, but, given a
+// WithCellsSeenBudget or WithRowsSeenBudget option, aborts the scan and
+// returns ErrReadBudgetExceeded once the accumulated FullReadStats cross
+// that budget. For a full-table scan (rs == nil) the budget is checked
+// every defaultReadBudgetBatchRows rows, so it may be exceeded by up to
+// one batch's worth of cells before the abort takes effect; for any
+// other RowSet, which can't generically be resumed from a watermark the
+// way a full scan can, the budget is only checked once the call
+// completes.
+func (t *Table) ReadRowsWithBudget(ctx context.Context, rs RowSet, f func(Row) bool, opts ...ReadOption) error {
+	var budget readBudget
+	var rest []ReadOption
+	for _, o := range opts {
+		switch b := o.(type) {
+		case cellsSeenBudgetOption:
+			budget.cellsSeen = b.n
+		case rowsSeenBudgetOption:
+			budget.rowsSeen = b.n
+		default:
+			rest = append(rest, o)
+		}
+	}
+	if budget.cellsSeen <= 0 && budget.rowsSeen <= 0 {
+		return t.ReadRows(ctx, rs, f, rest...)
+	}
+	if rs != nil {
+		return t.readRowsWithBudgetOnce(ctx, rs, budget, rest, f)
+	}
+
+	var total FullReadStats
+	lastKey := ""
+	for {
+		var n int
+		var stopped bool
+		batchOpts := append(append([]ReadOption{}, rest...), LimitRows(defaultReadBudgetBatchRows), WithFullReadStats(func(s *FullReadStats) {
+			mergeReadIterationStats(&total, s)
+		}))
+		err := t.ReadRows(ctx, InfiniteRange(lastKey), func(r Row) bool {
+			n++
+			lastKey = r.Key() + "\x00"
+			if !f(r) {
+				stopped = true
+				return false
+			}
+			return true
+		}, batchOpts...)
+		if err != nil {
+			return err
+		}
+		if budgetExceeded(total, budget) {
+			return ErrReadBudgetExceeded
+		}
+		if stopped || n < defaultReadBudgetBatchRows {
+			return nil
+		}
+	}
+}
+
+func (t *Table) readRowsWithBudgetOnce(ctx context.Context, rs RowSet, budget readBudget, opts []ReadOption, f func(Row) bool) error {
+	var stats FullReadStats
+	opts = append(append([]ReadOption{}, opts...), WithFullReadStats(func(s *FullReadStats) { stats = *s }))
+	if err := t.ReadRows(ctx, rs, f, opts...); err != nil {
+		return err
+	}
+	if budgetExceeded(stats, budget) {
+		return ErrReadBudgetExceeded
+	}
+	return nil
+}
+
+func budgetExceeded(stats FullReadStats, budget readBudget) bool {
+	if budget.cellsSeen > 0 && stats.ReadIterationStats.CellsSeenCount >= budget.cellsSeen {
+		return true
+	}
+	if budget.rowsSeen > 0 && stats.ReadIterationStats.RowsSeenCount >= budget.rowsSeen {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ReconcileOptions configures ReconcileInstanceWithClusters.
+type ReconcileOptions struct {
+	// PruneClusters allows ReconcileInstanceWithClusters to delete
+	// clusters present in the instance but missing from conf.Clusters.
+	// It sets conf.AllowClusterDeletion, the same guard
+	// UpdateInstanceAndPlanClusters/UpdateInstancePlan.Apply use, under a
+	// name that reads better at a call site built around ReconcileOptions.
+	PruneClusters bool
+	// Concurrency caps how many cluster create/update/delete calls run at
+	// once. Zero or negative means 1 (sequential).
+	Concurrency int
+	// DryRun, if true, skips execution entirely: ReconcileInstanceWithClusters
+	// returns the ReconcileResult's Planned actions without running any of
+	// them, and Ran is always empty.
+	DryRun bool
+}
+
+// ReconcileAction describes one step ReconcileInstanceWithClusters took
+// (or, under DryRun, would take) against a cluster.
+type ReconcileAction struct {
+	ClusterID string
+	// Verb is "create", "update", or "delete".
+	Verb string
+}
+
+// ReconcileResult is the outcome of ReconcileInstanceWithClusters.
+type ReconcileResult struct {
+	// InstanceUpdated reports whether the instance's DisplayName,
+	// InstanceType, or Labels were (or, under DryRun, would be) patched.
+	InstanceUpdated bool
+	// Planned lists every cluster action the reconcile computed, whether
+	// or not DryRun skipped executing it.
+	Planned []ReconcileAction
+	// Ran lists the subset of Planned that completed without error. Under
+	// DryRun it's always empty; otherwise every action is attempted, and
+	// each one that succeeds is listed, including ones that finished after
+	// a sibling action failed.
+	Ran []ReconcileAction
+	// NoOp is true when InstanceUpdated is false and Planned is empty —
+	// the instance already matched conf, nothing to reconcile.
+	NoOp bool
+}
+
+// ReconcileInstanceWithClusters diffs the instance's current state
+// against conf and converges it: patches the instance's DisplayName,
+// InstanceType, and Labels if they differ, creates clusters in
+// conf.Clusters missing from the instance, updates existing clusters
+// whose NumNodes or AutoscalingConfig differ, and — if
+// opts.PruneClusters is set — deletes clusters present in the instance
+// but missing from conf.Clusters.
+//
+// It's built on UpdateInstanceAndPlanClusters: computing the plan, then
+// — unless opts.DryRun — running its cluster actions up to
+// opts.Concurrency at a time. Every planned action is attempted even if
+// another fails; failures are aggregated with errors.Join into a single
+// error instead of UpdateInstancePlan.Apply's stop-at-the-first-error,
+// opaque-wrapped-error behavior, and the returned ReconcileResult always
+// reports what ran (or would run) regardless of success.
+//
+// Unlike UpdateInstancePlan.Apply, ReconcileInstanceWithClusters doesn't
+// interleave deletions and creations to protect instance capacity: with
+// opts.Concurrency greater than 1, deletions and creations race, so a
+// reconcile that both creates and prunes clusters may transiently drop
+// below the instance's starting node count if deletions finish first.
+// Use opts.Concurrency 1, or Apply directly, when that ordering matters.
+func ReconcileInstanceWithClusters(ctx context.Context, iac *InstanceAdminClient, conf *InstanceWithClustersConfig, opts ReconcileOptions) (*ReconcileResult, error) {
+	if opts.PruneClusters {
+		conf.AllowClusterDeletion = true
+	}
+
+	plan, err := UpdateInstanceAndPlanClusters(ctx, iac, conf)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.AllowClusterDeletion {
+		plan.ClustersToDelete = nil
+	}
+
+	result := &ReconcileResult{InstanceUpdated: plan.InstanceWillUpdate}
+	for _, c := range plan.ClustersToCreate {
+		result.Planned = append(result.Planned, ReconcileAction{ClusterID: c.ClusterID, Verb: "create"})
+	}
+	for _, c := range plan.ClustersToUpdate {
+		result.Planned = append(result.Planned, ReconcileAction{ClusterID: c.ClusterID, Verb: "update"})
+	}
+	for _, id := range plan.ClustersToDelete {
+		result.Planned = append(result.Planned, ReconcileAction{ClusterID: id, Verb: "delete"})
+	}
+	result.NoOp = !result.InstanceUpdated && len(result.Planned) == 0
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if plan.InstanceWillUpdate {
+		if _, err := iac.updateInstance(ctx, conf); err != nil {
+			return result, err
+		}
+	}
+
+	type reconcileStep struct {
+		action ReconcileAction
+		run    func(context.Context) error
+	}
+	var steps []reconcileStep
+	for _, c := range plan.ClustersToCreate {
+		c := c
+		steps = append(steps, reconcileStep{
+			action: ReconcileAction{ClusterID: c.ClusterID, Verb: "create"},
+			run:    func(ctx context.Context) error { return iac.CreateCluster(ctx, &c) },
+		})
+	}
+	for _, c := range plan.ClustersToUpdate {
+		c := c
+		steps = append(steps, reconcileStep{
+			action: ReconcileAction{ClusterID: c.ClusterID, Verb: "update"},
+			run: func(ctx context.Context) error {
+				if c.AutoscalingConfig != nil {
+					return iac.SetAutoscaling(ctx, conf.InstanceID, c.ClusterID, *c.AutoscalingConfig)
+				}
+				return iac.UpdateCluster(ctx, conf.InstanceID, c.ClusterID, c.NumNodes)
+			},
+		})
+	}
+	for _, id := range plan.ClustersToDelete {
+		id := id
+		steps = append(steps, reconcileStep{
+			action: ReconcileAction{ClusterID: id, Verb: "delete"},
+			run:    func(ctx context.Context) error { return iac.DeleteCluster(ctx, conf.InstanceID, id) },
+		})
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, s := range steps {
+		s := s
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := s.run(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s cluster %q: %w", s.action.Verb, s.action.ClusterID, err))
+				mu.Unlock()
+				return
+			}
+			mu.Lock()
+			result.Ran = append(result.Ran, s.action)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return result, errors.Join(errs...)
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RolloutStep is one stage of a staged app profile rollout: traffic is
+// sampled against the shadow profile for at least MinDuration, then
+// HealthCheck reports the error rate observed. How traffic reaches the
+// shadow profile during that window is up to the caller — e.g. opening
+// a table against the shadow profile ID and mirroring a fraction of
+// production calls to it, or querying Cloud Monitoring for the shadow
+// profile's reported error rate.
+type RolloutStep struct {
+	// Weight is informational only; it documents what fraction of
+	// traffic this step is meant to represent and isn't enforced here.
+	Weight float64
+	// MinDuration is how long to wait before calling HealthCheck.
+	MinDuration time.Duration
+	// HealthCheck reports the error rate observed against the shadow
+	// profile (shadowProfileID) so far, as a fraction between 0 and 1.
+	HealthCheck func(ctx context.Context, shadowProfileID string) (errorRate float64, err error)
+}
+
+// RolloutConfig describes a staged rollout: a sequence of RolloutSteps,
+// each raising confidence before the next, and an error rate threshold
+// that aborts the rollout if crossed at any step.
+type RolloutConfig struct {
+	Steps []RolloutStep
+	// AbortOn is the error rate threshold: if any step's HealthCheck
+	// reports an error rate at or above AbortOn, the rollout is aborted
+	// and rolled back.
+	AbortOn float64
+}
+
+// RolloutAppProfile stages profileID's update to updateAttrs through a
+// temporary shadow app profile rather than flipping the primary profile
+// atomically. It creates the shadow with updateAttrs' target
+// RoutingConfig and Isolation, runs updateAttrs.Rollout.Steps in order —
+// waiting each step's MinDuration, then calling its HealthCheck — and
+// aborts (deleting the shadow, leaving the primary untouched) the
+// moment any step reports an error rate at or above Rollout.AbortOn.
+// Only once every step passes does it promote the change by calling
+// UpdateAppProfile on the real primary profile, then deletes the shadow.
+//
+// updateAttrs.RoutingConfig must be set, since the shadow profile needs
+// a complete routing policy to create rather than a partial patch; if
+// it's nil, use UpdateAppProfile directly instead. updateAttrs.Rollout
+// must be set with at least one step, or RolloutAppProfile falls back to
+// calling UpdateAppProfile directly.
+func (iac *InstanceAdminClient) RolloutAppProfile(ctx context.Context, instanceID, profileID string, updateAttrs ProfileAttrsToUpdate) error {
+	if updateAttrs.Rollout == nil || len(updateAttrs.Rollout.Steps) == 0 {
+		return iac.UpdateAppProfile(ctx, instanceID, profileID, updateAttrs)
+	}
+	if updateAttrs.RoutingConfig == nil {
+		return errors.New("bigtable: RolloutAppProfile requires updateAttrs.RoutingConfig to create the shadow profile")
+	}
+
+	shadowID := fmt.Sprintf("%s-rollout-%d", profileID, time.Now().UnixNano())
+	shadowConf := ProfileConf{
+		InstanceID:     instanceID,
+		ProfileID:      shadowID,
+		Description:    fmt.Sprintf("temporary shadow profile for staged rollout of %q", profileID),
+		RoutingConfig:  updateAttrs.RoutingConfig,
+		Isolation:      updateAttrs.Isolation,
+		IgnoreWarnings: updateAttrs.IgnoreWarnings,
+	}
+	if _, err := iac.CreateAppProfile(ctx, shadowConf); err != nil {
+		return fmt.Errorf("bigtable: creating shadow app profile for rollout: %w", err)
+	}
+
+	rollback := func(cause error) error {
+		if delErr := iac.DeleteAppProfile(ctx, instanceID, shadowID); delErr != nil {
+			return fmt.Errorf("%w (also failed to delete shadow profile %q: %v)", cause, shadowID, delErr)
+		}
+		return cause
+	}
+
+	for i, step := range updateAttrs.Rollout.Steps {
+		if step.MinDuration > 0 {
+			select {
+			case <-time.After(step.MinDuration):
+			case <-ctx.Done():
+				return rollback(ctx.Err())
+			}
+		}
+		if step.HealthCheck == nil {
+			continue
+		}
+		errorRate, err := step.HealthCheck(ctx, shadowID)
+		if err != nil {
+			return rollback(fmt.Errorf("bigtable: rollout step %d health check: %w", i, err))
+		}
+		if errorRate >= updateAttrs.Rollout.AbortOn {
+			return rollback(fmt.Errorf("bigtable: rollout step %d error rate %.4f reached AbortOn threshold %.4f", i, errorRate, updateAttrs.Rollout.AbortOn))
+		}
+	}
+
+	if err := iac.UpdateAppProfile(ctx, instanceID, profileID, updateAttrs); err != nil {
+		return rollback(fmt.Errorf("bigtable: promoting rollout to primary profile %q: %w", profileID, err))
+	}
+	if err := iac.DeleteAppProfile(ctx, instanceID, shadowID); err != nil {
+		return fmt.Errorf("bigtable: rollout promoted successfully but failed to delete shadow profile %q: %w", shadowID, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"sync"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"google.golang.org/api/iterator"
+)
+
+const defaultRowIterBuffer = 32
+
+// iterBufferSize is a ReadOption recognized only by ReadRowsIter; it has no
+// effect on the ReadRows RPC itself, so it's a no-op everywhere else
+// ReadOptions are consumed.
+type iterBufferSize int
+
+func (iterBufferSize) set(*btpb.ReadRowsRequest) {}
+
+// WithIterBufferSize overrides the default number of rows ReadRowsIter
+// buffers between its background read and the consumer calling Next,
+// trading memory for how far ahead of the consumer the read is allowed to
+// run.
+func WithIterBufferSize(n int) ReadOption {
+	return iterBufferSize(n)
+}
+
+// RowIterator iterates over the rows of a ReadRows call, pulled one at a
+// time via Next, as an alternative to the callback form of ReadRows for
+// callers composing reads with select, pipelines, or errgroup.
+//
+// A RowIterator must eventually be drained to iterator.Done or have Stop
+// called, or its background goroutine and gRPC stream will leak.
+type RowIterator struct {
+	cancel context.CancelFunc
+	rows   chan Row
+	done   chan struct{}
+
+	mu    sync.Mutex
+	err   error
+	stats *FullReadStats
+}
+
+// ReadRowsIter is like ReadRows, but returns a RowIterator instead of
+// invoking a callback. The read runs in a background goroutine, streaming
+// rows into a channel of the size given by WithIterBufferSize (or
+// defaultRowIterBuffer, if unset).
+func (t *Table) ReadRowsIter(ctx context.Context, arg RowSet, opts ...ReadOption) *RowIterator {
+	buf := defaultRowIterBuffer
+	for _, opt := range opts {
+		if b, ok := opt.(iterBufferSize); ok {
+			buf = int(b)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	it := &RowIterator{
+		cancel: cancel,
+		rows:   make(chan Row, buf),
+		done:   make(chan struct{}),
+	}
+
+	statsOpt := WithFullReadStats(func(stats *FullReadStats) {
+		it.mu.Lock()
+		it.stats = stats
+		it.mu.Unlock()
+	})
+
+	go func() {
+		defer close(it.done)
+		err := t.ReadRows(ctx, arg, func(r Row) bool {
+			select {
+			case it.rows <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}, append(opts, statsOpt)...)
+		close(it.rows)
+
+		if err != nil && ctx.Err() == nil {
+			it.mu.Lock()
+			it.err = err
+			it.mu.Unlock()
+		}
+	}()
+
+	return it
+}
+
+// Next returns the next Row in the iteration. It returns iterator.Done once
+// the read completes with no more rows, and any RPC error encountered
+// otherwise. Once Next returns a non-nil error, every subsequent call
+// returns the same error.
+func (it *RowIterator) Next() (Row, error) {
+	r, ok := <-it.rows
+	if !ok {
+		it.mu.Lock()
+		defer it.mu.Unlock()
+		if it.err != nil {
+			return nil, it.err
+		}
+		return nil, iterator.Done
+	}
+	return r, nil
+}
+
+// Stop cancels the underlying ReadRows call and drains its goroutine. It is
+// safe to call Stop after Next has already returned iterator.Done, and
+// safe to call more than once.
+func (it *RowIterator) Stop() {
+	it.cancel()
+	for range it.rows {
+		// Drain rows already in flight so the background goroutine's send
+		// doesn't block forever on a consumer that has stopped reading.
+	}
+	<-it.done
+}
+
+// FullReadStats returns the read efficiency statistics for the completed
+// read, if WithFullReadStats was passed to ReadRowsIter. It's only valid
+// once Next has returned iterator.Done or an error, or after Stop.
+func (it *RowIterator) FullReadStats() *FullReadStats {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.stats
+}
@@ -0,0 +1,487 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// signBit flips an Int64Type's sign bit before big-endian encoding, so
+// that byte-wise comparison of the encoded form matches numeric order
+// (the whole point of BigEndianBytesEncoding) instead of putting every
+// negative value after every non-negative one.
+const signBit = uint64(1) << 63
+
+// EncodeKey encodes fields, given positionally in the same order as
+// s.Fields, into a row key conforming to s.Encoding. The caller must
+// supply exactly len(s.Fields) values; use NewRangeFromKeyFields to build
+// a RowRange from a shorter, prefix-only set of fields instead.
+func (s *StructType) EncodeKey(fields ...any) ([]byte, error) {
+	if len(fields) != len(s.Fields) {
+		return nil, fmt.Errorf("bigtable: EncodeKey got %d fields, schema has %d", len(fields), len(s.Fields))
+	}
+	parts := make([][]byte, len(s.Fields))
+	for i, f := range s.Fields {
+		b, err := encodeTypedField(f.FieldType, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("bigtable: encoding field %q: %w", f.FieldName, err)
+		}
+		parts[i] = b
+	}
+	return joinKeyParts(s.Encoding, s.Fields, parts)
+}
+
+// DecodeKey parses key according to s, returning a map from field name to
+// decoded value, in the same Go types EncodeKey accepts for that field
+// (int64 for Int64Type, string for StringType).
+func (s *StructType) DecodeKey(key []byte) (map[string]any, error) {
+	parts, err := splitKeyParts(s.Encoding, len(s.Fields), key)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any, len(s.Fields))
+	for i, f := range s.Fields {
+		v, err := decodeTypedField(f.FieldType, parts[i])
+		if err != nil {
+			return nil, fmt.Errorf("bigtable: decoding field %q: %w", f.FieldName, err)
+		}
+		out[f.FieldName] = v
+	}
+	return out, nil
+}
+
+func joinKeyParts(enc any, fields []StructField, parts [][]byte) ([]byte, error) {
+	switch enc := enc.(type) {
+	case StructSingletonEncoding:
+		if len(parts) != 1 {
+			return nil, fmt.Errorf("bigtable: StructSingletonEncoding requires exactly one field, schema has %d", len(parts))
+		}
+		return parts[0], nil
+	case StructDelimitedBytesEncoding:
+		delim := enc.Delimiter
+		if len(delim) == 0 {
+			delim = []byte{0}
+		}
+		for i, p := range parts {
+			if bytes.Contains(p, delim) {
+				return nil, fmt.Errorf("bigtable: field %q encodes to bytes containing the delimiter", fields[i].FieldName)
+			}
+		}
+		return bytes.Join(parts, delim), nil
+	case StructOrderedCodeBytesEncoding:
+		var buf bytes.Buffer
+		for _, p := range parts {
+			writeOrderedCodePart(&buf, p)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("bigtable: unsupported StructType encoding %T", enc)
+	}
+}
+
+func splitKeyParts(enc any, n int, key []byte) ([][]byte, error) {
+	switch enc := enc.(type) {
+	case StructSingletonEncoding:
+		if n != 1 {
+			return nil, fmt.Errorf("bigtable: StructSingletonEncoding requires exactly one field, schema has %d", n)
+		}
+		return [][]byte{key}, nil
+	case StructDelimitedBytesEncoding:
+		delim := enc.Delimiter
+		if len(delim) == 0 {
+			delim = []byte{0}
+		}
+		parts := bytes.Split(key, delim)
+		if len(parts) != n {
+			return nil, fmt.Errorf("bigtable: key has %d delimited fields, schema has %d", len(parts), n)
+		}
+		return parts, nil
+	case StructOrderedCodeBytesEncoding:
+		return readOrderedCodeParts(key, n)
+	default:
+		return nil, fmt.Errorf("bigtable: unsupported StructType encoding %T", enc)
+	}
+}
+
+// writeOrderedCodePart appends b to buf self-delimited by escaping every
+// 0x00 byte in b as 0x00 0xFF and terminating with 0x00 0x00, the same
+// escape-and-terminate scheme ordered-code key encodings (e.g.
+// FoundationDB's tuple layer) use so concatenated fields stay
+// self-delimiting without breaking byte-wise ordering: the previous
+// varint length prefix this replaced did not round-trip order for
+// variable-length fields (a longer field's length byte could compare
+// greater than a shorter field's leading content byte, e.g. "b" encoded
+// as [1 98] sorting before "aa" encoded as [2 97 97] even though "b" is
+// lexicographically after "aa"). Escaping preserves order because 0x00
+// is lower than every other byte, so the terminator 0x00 0x00 always
+// sorts before an escaped 0x00 0xFF, and neither can appear in the
+// unescaped tail of b.
+func writeOrderedCodePart(buf *bytes.Buffer, b []byte) {
+	for _, c := range b {
+		if c == 0x00 {
+			buf.WriteByte(0x00)
+			buf.WriteByte(0xFF)
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte(0x00)
+	buf.WriteByte(0x00)
+}
+
+func readOrderedCodeParts(key []byte, n int) ([][]byte, error) {
+	parts := make([][]byte, 0, n)
+	rest := key
+	for i := 0; i < n; i++ {
+		part, consumed, err := readOneOrderedCodePart(rest)
+		if err != nil {
+			return nil, fmt.Errorf("bigtable: malformed ordered-code key: field %d: %w", i, err)
+		}
+		parts = append(parts, part)
+		rest = rest[consumed:]
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("bigtable: malformed ordered-code key: %d trailing bytes", len(rest))
+	}
+	return parts, nil
+}
+
+// readOneOrderedCodePart reads the first escape-and-terminate-encoded
+// part off rest (see writeOrderedCodePart), returning the decoded part
+// and how many bytes of rest it consumed, including the terminator.
+func readOneOrderedCodePart(rest []byte) ([]byte, int, error) {
+	var part []byte
+	i := 0
+	for {
+		idx := bytes.IndexByte(rest[i:], 0x00)
+		if idx < 0 {
+			return nil, 0, errors.New("missing terminator")
+		}
+		idx += i
+		if idx+1 >= len(rest) {
+			return nil, 0, errors.New("truncated escape sequence")
+		}
+		switch rest[idx+1] {
+		case 0xFF:
+			part = append(part, rest[i:idx]...)
+			part = append(part, 0x00)
+			i = idx + 2
+		case 0x00:
+			part = append(part, rest[i:idx]...)
+			return part, idx + 2, nil
+		default:
+			return nil, 0, fmt.Errorf("invalid escape byte 0x%02x after 0x00", rest[idx+1])
+		}
+	}
+}
+
+func encodeTypedField(t Type, v any) ([]byte, error) {
+	switch ft := t.(type) {
+	case Int64Type:
+		i, ok := v.(int64)
+		if !ok {
+			return nil, fmt.Errorf("want int64, got %T", v)
+		}
+		switch ft.Encoding.(type) {
+		case BigEndianBytesEncoding, nil:
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, uint64(i)^signBit)
+			return b, nil
+		default:
+			return nil, fmt.Errorf("unsupported Int64Type encoding %T", ft.Encoding)
+		}
+	case StringType:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("want string, got %T", v)
+		}
+		switch ft.Encoding.(type) {
+		case StringUtf8BytesEncoding, nil:
+			return []byte(s), nil
+		default:
+			return nil, fmt.Errorf("unsupported StringType encoding %T", ft.Encoding)
+		}
+	case Float32Type:
+		f, ok := v.(float32)
+		if !ok {
+			return nil, fmt.Errorf("want float32, got %T", v)
+		}
+		switch ft.Encoding.(type) {
+		case IEEE754OrderedBytesEncoding, nil:
+			b := make([]byte, 4)
+			binary.BigEndian.PutUint32(b, orderPreservingFloat32Bits(math.Float32bits(f)))
+			return b, nil
+		default:
+			return nil, fmt.Errorf("unsupported Float32Type encoding %T", ft.Encoding)
+		}
+	case Float64Type:
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("want float64, got %T", v)
+		}
+		switch ft.Encoding.(type) {
+		case IEEE754OrderedBytesEncoding, nil:
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, orderPreservingFloat64Bits(math.Float64bits(f)))
+			return b, nil
+		default:
+			return nil, fmt.Errorf("unsupported Float64Type encoding %T", ft.Encoding)
+		}
+	case TimestampType:
+		ts, ok := v.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("want time.Time, got %T", v)
+		}
+		reversed := false
+		switch enc := ft.Encoding.(type) {
+		case Int64MicrosecondsBigEndianEncoding:
+			reversed = enc.Reversed
+		case nil:
+		default:
+			return nil, fmt.Errorf("unsupported TimestampType encoding %T", ft.Encoding)
+		}
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, uint64(ts.UnixMicro())^signBit)
+		if reversed {
+			invertBytes(b)
+		}
+		return b, nil
+	case ArrayType:
+		arr, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("want []any, got %T", v)
+		}
+		var buf bytes.Buffer
+		var countBuf [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(countBuf[:], uint64(len(arr)))
+		buf.Write(countBuf[:n])
+		for i, el := range arr {
+			eb, err := encodeTypedField(ft.ElementType, el)
+			if err != nil {
+				return nil, fmt.Errorf("encoding element %d: %w", i, err)
+			}
+			writeOrderedCodePart(&buf, eb)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %T", t)
+	}
+}
+
+// float32SignBit is the IEEE 754 single-precision sign bit.
+const float32SignBit = uint32(1) << 31
+
+// float64SignBit is the IEEE 754 double-precision sign bit.
+const float64SignBit = uint64(1) << 63
+
+// orderPreservingFloat32Bits transforms an IEEE 754 bit pattern so that
+// unsigned big-endian comparison of the result matches the float's
+// numeric order: the sign bit is flipped for non-negative values (so they
+// sort after every negative value), and every bit is inverted for
+// negative values (so more-negative values, which have a larger
+// magnitude bit pattern, sort first). fromOrderPreservingFloat32Bits
+// undoes it.
+func orderPreservingFloat32Bits(bits uint32) uint32 {
+	if bits&float32SignBit != 0 {
+		return ^bits
+	}
+	return bits | float32SignBit
+}
+
+// orderPreservingFloat64Bits is orderPreservingFloat32Bits for float64.
+func orderPreservingFloat64Bits(bits uint64) uint64 {
+	if bits&float64SignBit != 0 {
+		return ^bits
+	}
+	return bits | float64SignBit
+}
+
+// fromOrderPreservingFloat32Bits is the inverse of orderPreservingFloat32Bits.
+func fromOrderPreservingFloat32Bits(bits uint32) uint32 {
+	if bits&float32SignBit != 0 {
+		return bits &^ float32SignBit
+	}
+	return ^bits
+}
+
+// fromOrderPreservingFloat64Bits is the inverse of orderPreservingFloat64Bits.
+func fromOrderPreservingFloat64Bits(bits uint64) uint64 {
+	if bits&float64SignBit != 0 {
+		return bits &^ float64SignBit
+	}
+	return ^bits
+}
+
+// invertBytes flips every bit of b in place, turning an ascending
+// big-endian encoding into a descending one.
+func invertBytes(b []byte) {
+	for i, c := range b {
+		b[i] = ^c
+	}
+}
+
+func decodeTypedField(t Type, b []byte) (any, error) {
+	switch ft := t.(type) {
+	case Int64Type:
+		switch ft.Encoding.(type) {
+		case BigEndianBytesEncoding, nil:
+			if len(b) != 8 {
+				return nil, fmt.Errorf("want 8 bytes for Int64Type, got %d", len(b))
+			}
+			u := binary.BigEndian.Uint64(b) ^ signBit
+			return int64(u), nil
+		default:
+			return nil, fmt.Errorf("unsupported Int64Type encoding %T", ft.Encoding)
+		}
+	case StringType:
+		switch ft.Encoding.(type) {
+		case StringUtf8BytesEncoding, nil:
+			return string(b), nil
+		default:
+			return nil, fmt.Errorf("unsupported StringType encoding %T", ft.Encoding)
+		}
+	case Float32Type:
+		switch ft.Encoding.(type) {
+		case IEEE754OrderedBytesEncoding, nil:
+			if len(b) != 4 {
+				return nil, fmt.Errorf("want 4 bytes for Float32Type, got %d", len(b))
+			}
+			bits := fromOrderPreservingFloat32Bits(binary.BigEndian.Uint32(b))
+			return math.Float32frombits(bits), nil
+		default:
+			return nil, fmt.Errorf("unsupported Float32Type encoding %T", ft.Encoding)
+		}
+	case Float64Type:
+		switch ft.Encoding.(type) {
+		case IEEE754OrderedBytesEncoding, nil:
+			if len(b) != 8 {
+				return nil, fmt.Errorf("want 8 bytes for Float64Type, got %d", len(b))
+			}
+			bits := fromOrderPreservingFloat64Bits(binary.BigEndian.Uint64(b))
+			return math.Float64frombits(bits), nil
+		default:
+			return nil, fmt.Errorf("unsupported Float64Type encoding %T", ft.Encoding)
+		}
+	case TimestampType:
+		reversed := false
+		switch enc := ft.Encoding.(type) {
+		case Int64MicrosecondsBigEndianEncoding:
+			reversed = enc.Reversed
+		case nil:
+		default:
+			return nil, fmt.Errorf("unsupported TimestampType encoding %T", ft.Encoding)
+		}
+		if len(b) != 8 {
+			return nil, fmt.Errorf("want 8 bytes for TimestampType, got %d", len(b))
+		}
+		if reversed {
+			rev := append([]byte(nil), b...)
+			invertBytes(rev)
+			b = rev
+		}
+		u := binary.BigEndian.Uint64(b) ^ signBit
+		return time.UnixMicro(int64(u)).UTC(), nil
+	case ArrayType:
+		count, sz := binary.Uvarint(b)
+		if sz <= 0 {
+			return nil, fmt.Errorf("malformed ArrayType: bad element count prefix")
+		}
+		parts, err := readOrderedCodeParts(b[sz:], int(count))
+		if err != nil {
+			return nil, err
+		}
+		arr := make([]any, len(parts))
+		for i, p := range parts {
+			v, err := decodeTypedField(ft.ElementType, p)
+			if err != nil {
+				return nil, fmt.Errorf("decoding element %d: %w", i, err)
+			}
+			arr[i] = v
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %T", t)
+	}
+}
+
+// KeyFields decodes r's key according to schema, equivalent to calling
+// schema.DecodeKey(r.Key()). It's a convenience for tables created with a
+// RowKeySchema (see TableConf.RowKeySchema).
+func (r Row) KeyFields(schema *StructType) (map[string]any, error) {
+	return schema.DecodeKey([]byte(r.Key()))
+}
+
+// NewRangeFromKeyFields returns a RowRange covering every row whose
+// schema-decoded key sorts between lo and hi, built by encoding lo and hi
+// through schema rather than requiring the caller to assemble raw key
+// bytes. lo and hi may each supply a strict prefix of schema.Fields (by
+// name) to build an open-ended bound over the remaining fields; a nil or
+// empty map encodes to the unbounded end of the range. A partial field
+// set is only meaningful for StructDelimitedBytesEncoding and
+// StructOrderedCodeBytesEncoding, since both encode each field
+// independently; StructSingletonEncoding requires schema to have exactly
+// one field, so lo and hi must supply it or omit it entirely.
+func NewRangeFromKeyFields(schema *StructType, lo, hi map[string]any) (RowRange, error) {
+	loKey, err := schema.encodeKeyPrefix(lo)
+	if err != nil {
+		return RowRange{}, fmt.Errorf("bigtable: encoding lo: %w", err)
+	}
+	hiKey, err := schema.encodeKeyPrefix(hi)
+	if err != nil {
+		return RowRange{}, fmt.Errorf("bigtable: encoding hi: %w", err)
+	}
+	if hiKey == "" {
+		return InfiniteRange(loKey), nil
+	}
+	return NewRange(loKey, hiKey), nil
+}
+
+// encodeKeyPrefix is like EncodeKey, but accepts a leading subset of
+// s.Fields (by name, via fields) instead of requiring every field, for
+// building prefix bounds with NewRangeFromKeyFields.
+func (s *StructType) encodeKeyPrefix(fields map[string]any) (string, error) {
+	var parts [][]byte
+	var used []StructField
+	for _, f := range s.Fields {
+		v, ok := fields[f.FieldName]
+		if !ok {
+			break
+		}
+		b, err := encodeTypedField(f.FieldType, v)
+		if err != nil {
+			return "", fmt.Errorf("bigtable: encoding field %q: %w", f.FieldName, err)
+		}
+		parts = append(parts, b)
+		used = append(used, f)
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	if _, ok := s.Encoding.(StructSingletonEncoding); ok && len(parts) != len(s.Fields) {
+		return "", fmt.Errorf("bigtable: StructSingletonEncoding requires its one field to be present in full")
+	}
+	b, err := joinKeyParts(s.Encoding, used, parts)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
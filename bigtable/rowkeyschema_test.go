@@ -0,0 +1,177 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStructTypeEncodeDecodeKeyRoundTrip(t *testing.T) {
+	ts := time.UnixMicro(1234567890).UTC()
+	for _, test := range []struct {
+		desc   string
+		schema *StructType
+		fields []any
+	}{
+		{
+			desc:   "singleton",
+			schema: &StructType{Fields: []StructField{{FieldName: "id", FieldType: Int64Type{}}}, Encoding: StructSingletonEncoding{}},
+			fields: []any{int64(42)},
+		},
+		{
+			desc: "delimited bytes, mixed types",
+			schema: &StructType{
+				Fields: []StructField{
+					{FieldName: "tenant", FieldType: StringType{}},
+					{FieldName: "id", FieldType: Int64Type{}},
+				},
+				Encoding: StructDelimitedBytesEncoding{Delimiter: []byte("#")},
+			},
+			fields: []any{"acme", int64(-7)},
+		},
+		{
+			desc: "ordered code, string then int64",
+			schema: &StructType{
+				Fields: []StructField{
+					{FieldName: "name", FieldType: StringType{}},
+					{FieldName: "id", FieldType: Int64Type{}},
+				},
+				Encoding: StructOrderedCodeBytesEncoding{},
+			},
+			fields: []any{"aa", int64(7)},
+		},
+		{
+			desc: "ordered code, floats and timestamp",
+			schema: &StructType{
+				Fields: []StructField{
+					{FieldName: "f32", FieldType: Float32Type{}},
+					{FieldName: "f64", FieldType: Float64Type{}},
+					{FieldName: "at", FieldType: TimestampType{}},
+				},
+				Encoding: StructOrderedCodeBytesEncoding{},
+			},
+			fields: []any{float32(-1.5), float64(2.25), ts},
+		},
+		{
+			desc: "ordered code, array of strings",
+			schema: &StructType{
+				Fields: []StructField{
+					{FieldName: "tags", FieldType: ArrayType{ElementType: StringType{}}},
+				},
+				Encoding: StructOrderedCodeBytesEncoding{},
+			},
+			fields: []any{[]any{"a", "bb", "ccc"}},
+		},
+	} {
+		key, err := test.schema.EncodeKey(test.fields...)
+		if err != nil {
+			t.Errorf("%s: EncodeKey got unexpected error: %v", test.desc, err)
+			continue
+		}
+		got, err := test.schema.DecodeKey(key)
+		if err != nil {
+			t.Errorf("%s: DecodeKey got unexpected error: %v", test.desc, err)
+			continue
+		}
+		for i, f := range test.schema.Fields {
+			if !reflect.DeepEqual(got[f.FieldName], test.fields[i]) {
+				t.Errorf("%s: field %q got %#v, want %#v", test.desc, f.FieldName, got[f.FieldName], test.fields[i])
+			}
+		}
+	}
+}
+
+// TestStructOrderedCodeBytesEncodingPreservesOrder is a regression test for
+// a bug where writeOrderedCodePart length-prefixed each field instead of
+// self-delimiting it: "b" encoded shorter than "aa" but with a leading
+// length byte (1) less than "aa"'s leading content byte (97 via its own
+// length-2 prefix), so bytes.Compare on the encoded forms disagreed with
+// the fields' own lexicographic order.
+func TestStructOrderedCodeBytesEncodingPreservesOrder(t *testing.T) {
+	schema := &StructType{
+		Fields:   []StructField{{FieldName: "s", FieldType: StringType{}}},
+		Encoding: StructOrderedCodeBytesEncoding{},
+	}
+	for _, test := range []struct {
+		lo, hi string
+	}{
+		{"aa", "b"},
+		{"a", "aa"},
+		{"", "a"},
+		{"abc", "abd"},
+	} {
+		loKey, err := schema.EncodeKey(test.lo)
+		if err != nil {
+			t.Fatalf("EncodeKey(%q): %v", test.lo, err)
+		}
+		hiKey, err := schema.EncodeKey(test.hi)
+		if err != nil {
+			t.Fatalf("EncodeKey(%q): %v", test.hi, err)
+		}
+		if bytes.Compare(loKey, hiKey) >= 0 {
+			t.Errorf("encoded %q (%v) did not sort before encoded %q (%v)", test.lo, loKey, test.hi, hiKey)
+		}
+	}
+}
+
+func TestStructTypeEncodeKeyFieldCountMismatch(t *testing.T) {
+	schema := &StructType{
+		Fields:   []StructField{{FieldName: "id", FieldType: Int64Type{}}},
+		Encoding: StructSingletonEncoding{},
+	}
+	if _, err := schema.EncodeKey(int64(1), int64(2)); err == nil {
+		t.Error("EncodeKey with too many fields got nil error, want error")
+	}
+}
+
+func TestNewRangeFromKeyFields(t *testing.T) {
+	schema := &StructType{
+		Fields: []StructField{
+			{FieldName: "tenant", FieldType: StringType{}},
+			{FieldName: "id", FieldType: Int64Type{}},
+		},
+		Encoding: StructOrderedCodeBytesEncoding{},
+	}
+
+	rr, err := NewRangeFromKeyFields(schema, map[string]any{"tenant": "acme"}, map[string]any{"tenant": "acmf"})
+	if err != nil {
+		t.Fatalf("NewRangeFromKeyFields got unexpected error: %v", err)
+	}
+	wantLo, err := schema.encodeKeyPrefix(map[string]any{"tenant": "acme"})
+	if err != nil {
+		t.Fatalf("encodeKeyPrefix(lo): %v", err)
+	}
+	wantHi, err := schema.encodeKeyPrefix(map[string]any{"tenant": "acmf"})
+	if err != nil {
+		t.Fatalf("encodeKeyPrefix(hi): %v", err)
+	}
+	want := NewRange(wantLo, wantHi)
+	if rr != want {
+		t.Errorf("NewRangeFromKeyFields got %+v, want %+v", rr, want)
+	}
+
+	rr, err = NewRangeFromKeyFields(schema, map[string]any{"tenant": "acme"}, nil)
+	if err != nil {
+		t.Fatalf("NewRangeFromKeyFields with nil hi got unexpected error: %v", err)
+	}
+	if want := InfiniteRange(wantLo); rr != want {
+		t.Errorf("NewRangeFromKeyFields with nil hi got %+v, want %+v", rr, want)
+	}
+}
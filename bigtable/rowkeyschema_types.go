@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+)
+
+// FloatEncoding specifies how a Float32Type or Float64Type value is
+// encoded to bytes within a row key.
+type FloatEncoding interface {
+	isFloatEncoding()
+}
+
+// IEEE754OrderedBytesEncoding encodes a float's IEEE 754 bit pattern so
+// that byte-wise lexicographic order matches numeric order: the sign bit
+// is flipped for non-negative values, and every bit is inverted for
+// negative values, the standard trick for making IEEE 754's sign-and-
+// magnitude layout compare correctly as big-endian bytes.
+type IEEE754OrderedBytesEncoding struct{}
+
+func (IEEE754OrderedBytesEncoding) isFloatEncoding() {}
+
+// Float32Type represents an IEEE 754 single-precision floating point
+// value, such as a sensor reading, used as a Family's ValueType or a
+// StructField's FieldType.
+type Float32Type struct {
+	Encoding FloatEncoding
+}
+
+func (Float32Type) proto() *btapb.Type {
+	return &btapb.Type{Kind: &btapb.Type_Float32Type{Float32Type: &btapb.Type_Float32{}}}
+}
+
+// Float64Type represents an IEEE 754 double-precision floating point
+// value.
+type Float64Type struct {
+	Encoding FloatEncoding
+}
+
+func (Float64Type) proto() *btapb.Type {
+	return &btapb.Type{Kind: &btapb.Type_Float64Type{Float64Type: &btapb.Type_Float64{}}}
+}
+
+// TimestampEncoding specifies how a TimestampType value is encoded to
+// bytes within a row key.
+type TimestampEncoding interface {
+	isTimestampEncoding()
+}
+
+// Int64MicrosecondsBigEndianEncoding encodes a timestamp as big-endian
+// microseconds since the Unix epoch, the same sign-flipped scheme
+// Int64Type's BigEndianBytesEncoding uses for integers, so chronological
+// order matches byte order. Set Reversed to make newer timestamps sort
+// first instead, for scans that want the most recent events first.
+type Int64MicrosecondsBigEndianEncoding struct {
+	Reversed bool
+}
+
+func (Int64MicrosecondsBigEndianEncoding) isTimestampEncoding() {}
+
+// TimestampType represents a point in time, such as an event's
+// occurrence time, encoded as microseconds since the Unix epoch.
+type TimestampType struct {
+	Encoding TimestampEncoding
+}
+
+func (TimestampType) proto() *btapb.Type {
+	return &btapb.Type{Kind: &btapb.Type_TimestampType{TimestampType: &btapb.Type_Timestamp{}}}
+}
+
+// ArrayType represents a repeated sequence of elements of ElementType,
+// such as an ordered list of session event IDs, used as a StructField's
+// FieldType. Its row key encoding is independent of StructType.Encoding:
+// EncodeKey/DecodeKey always write a varint element count followed by
+// each element in writeOrderedCodePart's escape-and-terminate form, since
+// an unambiguous element boundary is required regardless of how the
+// surrounding struct fields are joined.
+type ArrayType struct {
+	ElementType Type
+}
+
+func (a ArrayType) proto() *btapb.Type {
+	return &btapb.Type{Kind: &btapb.Type_ArrayType{ArrayType: &btapb.Type_Array{ElementType: a.ElementType.proto()}}}
+}
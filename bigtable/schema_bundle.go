@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// SchemaBundle indexes the message types described by a schema bundle's
+// serialized FileDescriptorSet (SchemaBundleInfo.SchemaBundle, as
+// returned by AdminClient.GetSchemaBundle) so application code can
+// encode and decode typed cell values without hand-rolling
+// proto.Marshal/Unmarshal and separately tracking which message type
+// belongs to which column.
+type SchemaBundle struct {
+	files   *protoregistry.Files
+	columns map[string]protoreflect.MessageDescriptor // "family:qualifier" -> message type
+}
+
+// ParseSchemaBundle parses protoDescriptors, a serialized
+// google.protobuf.FileDescriptorSet as stored in
+// SchemaBundleConf.ProtoSchema.ProtoDescriptors, and indexes its
+// messages by fully qualified name so they can be bound to columns with
+// BindColumn.
+func ParseSchemaBundle(protoDescriptors []byte) (*SchemaBundle, error) {
+	fdSet := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(protoDescriptors, fdSet); err != nil {
+		return nil, fmt.Errorf("bigtable: parsing schema bundle descriptors: %w", err)
+	}
+	files, err := protodesc.NewFiles(fdSet)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: building schema bundle file registry: %w", err)
+	}
+	return &SchemaBundle{files: files, columns: make(map[string]protoreflect.MessageDescriptor)}, nil
+}
+
+// BindColumn associates family:qualifier with messageName, a fully
+// qualified protobuf message name such as "myapp.v1.Order", so Encode
+// and Decode know which message type that column holds. messageName
+// must be one of the messages described by the bundle sb was parsed
+// from.
+func (sb *SchemaBundle) BindColumn(family, qualifier, messageName string) error {
+	desc, err := sb.files.FindDescriptorByName(protoreflect.FullName(messageName))
+	if err != nil {
+		return fmt.Errorf("bigtable: schema bundle has no message %q: %w", messageName, err)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return fmt.Errorf("bigtable: %q is not a message type", messageName)
+	}
+	sb.columns[columnKey(family, qualifier)] = md
+	return nil
+}
+
+func columnKey(family, qualifier string) string { return family + ":" + qualifier }
+
+// Encode marshals msg for storage in family:qualifier. msg's message
+// type must match the one BindColumn associated with that column.
+func (sb *SchemaBundle) Encode(family, qualifier string, msg proto.Message) ([]byte, error) {
+	md, ok := sb.columns[columnKey(family, qualifier)]
+	if !ok {
+		return nil, fmt.Errorf("bigtable: no message type bound to column %s:%s", family, qualifier)
+	}
+	if got := msg.ProtoReflect().Descriptor().FullName(); got != md.FullName() {
+		return nil, fmt.Errorf("bigtable: column %s:%s expects %s, got %s", family, qualifier, md.FullName(), got)
+	}
+	return proto.Marshal(msg)
+}
+
+// Decode unmarshals cell, a value previously written with Encode, as the
+// message type bound to family:qualifier. The result is a
+// *dynamicpb.Message built from the bundle's descriptors; callers that
+// have the concrete generated Go type for that message can instead
+// unmarshal cell into it directly and skip Decode.
+func (sb *SchemaBundle) Decode(family, qualifier string, cell []byte) (proto.Message, error) {
+	md, ok := sb.columns[columnKey(family, qualifier)]
+	if !ok {
+		return nil, fmt.Errorf("bigtable: no message type bound to column %s:%s", family, qualifier)
+	}
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(cell, msg); err != nil {
+		return nil, fmt.Errorf("bigtable: decoding column %s:%s: %w", family, qualifier, err)
+	}
+	return msg, nil
+}
+
+// SchemaBundleFromFileDescriptors builds the serialized
+// FileDescriptorSet bytes that SchemaBundleConf.ProtoSchema.ProtoDescriptors
+// expects, from a set of already-loaded file descriptors (e.g. the
+// File_xxx_proto values generated alongside a package's Go types) and
+// everything they transitively import.
+func SchemaBundleFromFileDescriptors(files ...protoreflect.FileDescriptor) ([]byte, error) {
+	fdSet := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		fdSet.File = append(fdSet.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, f := range files {
+		add(f)
+	}
+	return proto.Marshal(fdSet)
+}
+
+// ReadRowTyped is like ReadRow, but additionally decodes every cell
+// whose family:qualifier was bound in bundle, returning them alongside
+// the row's raw values. Cells in columns bundle has no binding for are
+// present in the returned Row as usual but omitted from the typed map.
+func (t *Table) ReadRowTyped(ctx context.Context, row string, bundle *SchemaBundle, opts ...ReadOption) (Row, map[string]proto.Message, error) {
+	r, err := t.ReadRow(ctx, row, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	typed := make(map[string]proto.Message)
+	for family, items := range r {
+		for _, item := range items {
+			qualifier := item.Column
+			if i := strings.IndexByte(qualifier, ':'); i >= 0 {
+				qualifier = qualifier[i+1:]
+			}
+			if _, bound := bundle.columns[columnKey(family, qualifier)]; !bound {
+				continue
+			}
+			msg, err := bundle.Decode(family, qualifier, item.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+			typed[columnKey(family, qualifier)] = msg
+		}
+	}
+	return r, typed, nil
+}
+
+// ApplyTyped is like Apply, but writes each entry of sets (keyed
+// "family:qualifier") as the typed proto message bundle encodes it to,
+// rather than requiring the caller to marshal values themselves.
+func (t *Table) ApplyTyped(ctx context.Context, row string, bundle *SchemaBundle, sets map[string]proto.Message, opts ...ApplyOption) error {
+	mut := NewMutation()
+	for column, msg := range sets {
+		family, qualifier, ok := strings.Cut(column, ":")
+		if !ok {
+			return fmt.Errorf("bigtable: ApplyTyped column %q must be \"family:qualifier\"", column)
+		}
+		val, err := bundle.Encode(family, qualifier, msg)
+		if err != nil {
+			return err
+		}
+		mut.Set(family, qualifier, ServerTime, val)
+	}
+	return t.Apply(ctx, row, mut, opts...)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaBundleValidationSeverity ranks how disruptive a
+// SchemaBundleValidationFinding is to existing readers of a schema
+// bundle's messages.
+type SchemaBundleValidationSeverity int
+
+const (
+	// SchemaBundleValidationInfo is a change with no semantic or wire
+	// impact on existing readers (e.g. an enum value renamed without its
+	// number changing).
+	SchemaBundleValidationInfo SchemaBundleValidationSeverity = iota
+	// SchemaBundleValidationWarning is a change unlikely to break
+	// existing readers but worth a human look (e.g. a field renamed
+	// without its number or type changing).
+	SchemaBundleValidationWarning
+	// SchemaBundleValidationBreaking is a change that can break existing
+	// readers (e.g. a removed field, a changed field type or number, or
+	// a singular/repeated cardinality flip).
+	SchemaBundleValidationBreaking
+)
+
+// SchemaBundleValidationFinding is one detected difference between two
+// versions of a schema bundle's descriptors.
+type SchemaBundleValidationFinding struct {
+	// Message is the fully qualified message or enum name the finding is
+	// about.
+	Message string
+	// Field is the field or enum value name the finding is about, or
+	// empty if the finding is about Message as a whole (e.g. "message
+	// removed").
+	Field    string
+	Severity SchemaBundleValidationSeverity
+	Detail   string
+}
+
+// SchemaBundleValidation is a compatibility report comparing two
+// versions of a schema bundle's descriptors, returned by
+// AdminClient.ValidateSchemaBundleUpdate and CompareProtoSchemas.
+type SchemaBundleValidation struct {
+	Findings []SchemaBundleValidationFinding
+}
+
+func (v *SchemaBundleValidation) add(message, field string, severity SchemaBundleValidationSeverity, detail string) {
+	v.Findings = append(v.Findings, SchemaBundleValidationFinding{Message: message, Field: field, Severity: severity, Detail: detail})
+}
+
+// HasBreakingChanges reports whether any finding in v has
+// SchemaBundleValidationBreaking severity.
+func (v *SchemaBundleValidation) HasBreakingChanges() bool {
+	for _, f := range v.Findings {
+		if f.Severity == SchemaBundleValidationBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSchemaBundleUpdate fetches conf.SchemaBundleConf's current
+// bundle and compares it against conf's new ProtoDescriptors with
+// CompareProtoSchemas, without otherwise calling UpdateSchemaBundle:
+// it's a way to inspect ahead of time the same kind of incompatibility
+// UpdateSchemaBundle's IgnoreWarnings flag lets a caller suppress at the
+// server.
+func (ac *AdminClient) ValidateSchemaBundleUpdate(ctx context.Context, conf UpdateSchemaBundleConf) (*SchemaBundleValidation, error) {
+	if conf.SchemaBundleConf.TableID == "" || conf.SchemaBundleConf.SchemaBundleID == "" {
+		return nil, errors.New("both SchemaBundleID and TableID are required in SchemaBundleConf")
+	}
+	current, err := ac.GetSchemaBundle(ctx, conf.SchemaBundleConf.TableID, conf.SchemaBundleConf.SchemaBundleID)
+	if err != nil {
+		return nil, err
+	}
+	var newDescriptors []byte
+	if conf.SchemaBundleConf.ProtoSchema != nil {
+		newDescriptors = conf.SchemaBundleConf.ProtoSchema.ProtoDescriptors
+	}
+	return CompareProtoSchemas(current.SchemaBundle, newDescriptors)
+}
+
+// CompareProtoSchemas compares old and new, each a serialized
+// google.protobuf.FileDescriptorSet as stored in
+// SchemaBundleConf.ProtoSchema.ProtoDescriptors, and reports breaking
+// changes per message: removed fields, changed field types, changed
+// field numbers, changed cardinality (singular vs. repeated), and
+// renamed enum values whose numeric value shifted. It operates purely on
+// the two descriptor sets, with no round trip to the admin API.
+func CompareProtoSchemas(old, new []byte) (*SchemaBundleValidation, error) {
+	oldFiles, err := parseSchemaBundleFiles(old)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: parsing old schema: %w", err)
+	}
+	newFiles, err := parseSchemaBundleFiles(new)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: parsing new schema: %w", err)
+	}
+
+	oldMessages := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	oldEnums := make(map[protoreflect.FullName]protoreflect.EnumDescriptor)
+	oldFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		collectSchemaDescriptors(fd, oldMessages, oldEnums)
+		return true
+	})
+	newMessages := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	newEnums := make(map[protoreflect.FullName]protoreflect.EnumDescriptor)
+	newFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		collectSchemaDescriptors(fd, newMessages, newEnums)
+		return true
+	})
+
+	v := &SchemaBundleValidation{}
+	for name, oldMD := range oldMessages {
+		newMD, ok := newMessages[name]
+		if !ok {
+			v.add(string(name), "", SchemaBundleValidationBreaking, "message removed")
+			continue
+		}
+		compareSchemaMessage(v, oldMD, newMD)
+	}
+	for name, oldEd := range oldEnums {
+		newEd, ok := newEnums[name]
+		if !ok {
+			v.add(string(name), "", SchemaBundleValidationBreaking, "enum removed")
+			continue
+		}
+		compareSchemaEnum(v, oldEd, newEd)
+	}
+	return v, nil
+}
+
+// schemaDescriptorContainer is satisfied by both
+// protoreflect.FileDescriptor and protoreflect.MessageDescriptor, the
+// two descriptor kinds that can directly nest messages and enums.
+type schemaDescriptorContainer interface {
+	Messages() protoreflect.MessageDescriptors
+	Enums() protoreflect.EnumDescriptors
+}
+
+// collectSchemaDescriptors recursively indexes every message and enum
+// reachable from c (including nested ones) by fully qualified name.
+func collectSchemaDescriptors(c schemaDescriptorContainer, messages map[protoreflect.FullName]protoreflect.MessageDescriptor, enums map[protoreflect.FullName]protoreflect.EnumDescriptor) {
+	msgs := c.Messages()
+	for i := 0; i < msgs.Len(); i++ {
+		md := msgs.Get(i)
+		messages[md.FullName()] = md
+		collectSchemaDescriptors(md, messages, enums)
+	}
+	es := c.Enums()
+	for i := 0; i < es.Len(); i++ {
+		ed := es.Get(i)
+		enums[ed.FullName()] = ed
+	}
+}
+
+func compareSchemaMessage(v *SchemaBundleValidation, oldMD, newMD protoreflect.MessageDescriptor) {
+	oldFields := oldMD.Fields()
+	newFields := newMD.Fields()
+	for i := 0; i < oldFields.Len(); i++ {
+		of := oldFields.Get(i)
+		nf := newFields.ByNumber(of.Number())
+		if nf == nil {
+			v.add(string(oldMD.FullName()), string(of.Name()), SchemaBundleValidationBreaking,
+				fmt.Sprintf("field %d (%s) removed", of.Number(), of.Name()))
+			continue
+		}
+		if nf.Name() != of.Name() {
+			v.add(string(oldMD.FullName()), string(of.Name()), SchemaBundleValidationWarning,
+				fmt.Sprintf("field %d renamed from %s to %s", of.Number(), of.Name(), nf.Name()))
+		}
+		if of.Kind() != nf.Kind() {
+			v.add(string(oldMD.FullName()), string(of.Name()), SchemaBundleValidationBreaking,
+				fmt.Sprintf("field %d (%s) type changed from %s to %s", of.Number(), of.Name(), of.Kind(), nf.Kind()))
+		} else {
+			switch of.Kind() {
+			case protoreflect.MessageKind, protoreflect.GroupKind:
+				if of.Message().FullName() != nf.Message().FullName() {
+					v.add(string(oldMD.FullName()), string(of.Name()), SchemaBundleValidationBreaking,
+						fmt.Sprintf("field %d (%s) message type changed from %s to %s", of.Number(), of.Name(), of.Message().FullName(), nf.Message().FullName()))
+				}
+			case protoreflect.EnumKind:
+				if of.Enum().FullName() != nf.Enum().FullName() {
+					v.add(string(oldMD.FullName()), string(of.Name()), SchemaBundleValidationBreaking,
+						fmt.Sprintf("field %d (%s) enum type changed from %s to %s", of.Number(), of.Name(), of.Enum().FullName(), nf.Enum().FullName()))
+				}
+			}
+		}
+		if of.Cardinality() != nf.Cardinality() {
+			v.add(string(oldMD.FullName()), string(of.Name()), SchemaBundleValidationBreaking,
+				fmt.Sprintf("field %d (%s) cardinality changed from %s to %s", of.Number(), of.Name(), of.Cardinality(), nf.Cardinality()))
+		}
+	}
+}
+
+func compareSchemaEnum(v *SchemaBundleValidation, oldEd, newEd protoreflect.EnumDescriptor) {
+	oldValues := oldEd.Values()
+	newValues := newEd.Values()
+	for i := 0; i < oldValues.Len(); i++ {
+		ov := oldValues.Get(i)
+		if nv := newValues.ByName(ov.Name()); nv != nil {
+			if nv.Number() != ov.Number() {
+				v.add(string(oldEd.FullName()), string(ov.Name()), SchemaBundleValidationBreaking,
+					fmt.Sprintf("enum value %s numeric value changed from %d to %d", ov.Name(), ov.Number(), nv.Number()))
+			}
+			continue
+		}
+		if nv := newValues.ByNumber(ov.Number()); nv != nil {
+			v.add(string(oldEd.FullName()), string(ov.Name()), SchemaBundleValidationInfo,
+				fmt.Sprintf("enum value %d renamed from %s to %s", ov.Number(), ov.Name(), nv.Name()))
+			continue
+		}
+		v.add(string(oldEd.FullName()), string(ov.Name()), SchemaBundleValidationBreaking,
+			fmt.Sprintf("enum value %s (%d) removed", ov.Name(), ov.Number()))
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fdSetBytes marshals a single-file FileDescriptorSet containing one
+// message (named "Msg") with fields, for feeding to CompareProtoSchemas.
+func fdSetBytes(t *testing.T, fields ...*descriptorpb.FieldDescriptorProto) []byte {
+	t.Helper()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Msg"), Field: fields},
+		},
+	}
+	b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fd}})
+	if err != nil {
+		t.Fatalf("marshaling FileDescriptorSet: %v", err)
+	}
+	return b
+}
+
+func strField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   typ.Enum(),
+		Label:  label.Enum(),
+	}
+}
+
+func TestCompareProtoSchemasFieldRemoved(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new := fdSetBytes(t)
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if !v.HasBreakingChanges() {
+		t.Fatal("HasBreakingChanges() = false, want true for a removed field")
+	}
+	if len(v.Findings) != 1 || v.Findings[0].Severity != SchemaBundleValidationBreaking {
+		t.Fatalf("Findings = %+v, want one breaking finding", v.Findings)
+	}
+}
+
+func TestCompareProtoSchemasFieldTypeChanged(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if !v.HasBreakingChanges() {
+		t.Error("HasBreakingChanges() = false, want true for a changed field type")
+	}
+}
+
+func TestCompareProtoSchemasCardinalityChanged(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED))
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if !v.HasBreakingChanges() {
+		t.Error("HasBreakingChanges() = false, want true for a changed cardinality")
+	}
+}
+
+func TestCompareProtoSchemasFieldRenamedSameNumber(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new := fdSetBytes(t, strField("b", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if v.HasBreakingChanges() {
+		t.Errorf("HasBreakingChanges() = true, want false for a same-number field rename; findings: %+v", v.Findings)
+	}
+	if len(v.Findings) != 1 || v.Findings[0].Severity != SchemaBundleValidationWarning {
+		t.Fatalf("Findings = %+v, want one warning finding", v.Findings)
+	}
+}
+
+func TestCompareProtoSchemasFieldAddedIsNotBreaking(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new := fdSetBytes(t,
+		strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+		strField("b", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+	)
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if len(v.Findings) != 0 {
+		t.Errorf("Findings = %+v, want none for an added field", v.Findings)
+	}
+}
+
+func TestCompareProtoSchemasIdenticalIsNotBreaking(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if len(v.Findings) != 0 {
+		t.Errorf("Findings = %+v, want none for identical schemas", v.Findings)
+	}
+}
+
+func TestCompareProtoSchemasMessageRemoved(t *testing.T) {
+	old := fdSetBytes(t, strField("a", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL))
+	new, err := proto.Marshal(&descriptorpb.FileDescriptorSet{})
+	if err != nil {
+		t.Fatalf("marshaling empty FileDescriptorSet: %v", err)
+	}
+
+	v, err := CompareProtoSchemas(old, new)
+	if err != nil {
+		t.Fatalf("CompareProtoSchemas: %v", err)
+	}
+	if !v.HasBreakingChanges() {
+		t.Fatal("HasBreakingChanges() = false, want true for a removed message")
+	}
+	if len(v.Findings) != 1 || v.Findings[0].Detail != "message removed" {
+		t.Fatalf("Findings = %+v, want one \"message removed\" finding", v.Findings)
+	}
+}
+
+func TestCompareProtoSchemasInvalidDescriptors(t *testing.T) {
+	if _, err := CompareProtoSchemas([]byte{0xff}, nil); err == nil {
+		t.Error("CompareProtoSchemas with invalid old descriptors got nil error, want error")
+	}
+}
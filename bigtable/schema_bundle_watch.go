@@ -0,0 +1,205 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// SchemaBundleEventType identifies how a schema bundle changed between
+// two SchemaBundleWatcher polls.
+type SchemaBundleEventType int
+
+const (
+	// SchemaBundleAdded means the bundle wasn't present on the previous
+	// poll.
+	SchemaBundleAdded SchemaBundleEventType = iota
+	// SchemaBundleModified means the bundle's Etag changed since the
+	// previous poll.
+	SchemaBundleModified
+	// SchemaBundleDeleted means the bundle was present on the previous
+	// poll but is no longer returned by the list.
+	SchemaBundleDeleted
+)
+
+// SchemaBundleEvent reports one schema bundle's change, delivered on
+// SchemaBundleWatcher's Events channel.
+type SchemaBundleEvent struct {
+	SchemaBundleID string
+	Type           SchemaBundleEventType
+	// Etag is the bundle's etag as of this event. Empty for
+	// SchemaBundleDeleted.
+	Etag string
+	// Files is the bundle's descriptors, parsed off the hot path before
+	// the event is delivered. Nil for SchemaBundleDeleted.
+	Files *protoregistry.Files
+}
+
+// SchemaBundleWatcher polls a table's schema bundles on an interval and
+// delivers a SchemaBundleEvent each time one is added, modified, or
+// removed, re-fetching and re-parsing only the bundles whose Etag
+// changed since the previous poll rather than every bundle every tick.
+// Build one with AdminClient.WatchSchemaBundles.
+type SchemaBundleWatcher struct {
+	ac       *AdminClient
+	tableID  string
+	interval time.Duration
+
+	events chan SchemaBundleEvent
+
+	mu    sync.Mutex
+	etags map[string]string // bundleID -> etag; the watcher's source of truth
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// WatchSchemaBundles starts polling tableID's schema bundles every
+// interval and returns a SchemaBundleWatcher delivering change events on
+// its Events channel, starting with an Added event for every bundle
+// tableID already has. Call Close (or cancel ctx) to stop it.
+func (ac *AdminClient) WatchSchemaBundles(ctx context.Context, tableID string, interval time.Duration) *SchemaBundleWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &SchemaBundleWatcher{
+		ac:       ac,
+		tableID:  tableID,
+		interval: interval,
+		events:   make(chan SchemaBundleEvent),
+		etags:    make(map[string]string),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go w.run(ctx)
+	return w
+}
+
+// Events returns the channel w delivers SchemaBundleEvents on. It's
+// closed once w has fully stopped, after Close or ctx cancellation.
+func (w *SchemaBundleWatcher) Events() <-chan SchemaBundleEvent {
+	return w.events
+}
+
+// Close stops w's polling goroutine and blocks until it has exited and
+// closed Events.
+func (w *SchemaBundleWatcher) Close() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *SchemaBundleWatcher) run(ctx context.Context) {
+	defer close(w.done)
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll lists the table's current schema bundles, diffs them against
+// w.etags, and delivers one event per bundle that was added, whose etag
+// changed, or that disappeared since the previous poll. A failed list
+// call is silently retried on the next tick rather than reported on
+// Events, since SchemaBundleWatcher has no error channel.
+func (w *SchemaBundleWatcher) poll(ctx context.Context) {
+	it := w.ac.SchemaBundleIterator(ctx, w.tableID)
+	seen := make(map[string]bool)
+	for {
+		sb, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return
+		}
+		seen[sb.SchemaBundleID] = true
+
+		w.mu.Lock()
+		prevEtag, existed := w.etags[sb.SchemaBundleID]
+		w.mu.Unlock()
+		if existed && prevEtag == sb.Etag {
+			continue
+		}
+
+		files, err := parseSchemaBundleFiles(sb.SchemaBundle)
+		if err != nil {
+			continue
+		}
+
+		w.mu.Lock()
+		w.etags[sb.SchemaBundleID] = sb.Etag
+		w.mu.Unlock()
+
+		eventType := SchemaBundleModified
+		if !existed {
+			eventType = SchemaBundleAdded
+		}
+		if !w.deliver(ctx, SchemaBundleEvent{SchemaBundleID: sb.SchemaBundleID, Type: eventType, Etag: sb.Etag, Files: files}) {
+			return
+		}
+	}
+
+	w.mu.Lock()
+	var removed []string
+	for id := range w.etags {
+		if !seen[id] {
+			removed = append(removed, id)
+		}
+	}
+	for _, id := range removed {
+		delete(w.etags, id)
+	}
+	w.mu.Unlock()
+
+	for _, id := range removed {
+		if !w.deliver(ctx, SchemaBundleEvent{SchemaBundleID: id, Type: SchemaBundleDeleted}) {
+			return
+		}
+	}
+}
+
+// deliver sends ev on w.events, returning false instead of blocking
+// forever if ctx is done first.
+func (w *SchemaBundleWatcher) deliver(ctx context.Context, ev SchemaBundleEvent) bool {
+	select {
+	case w.events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func parseSchemaBundleFiles(protoDescriptors []byte) (*protoregistry.Files, error) {
+	sb, err := ParseSchemaBundle(protoDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	return sb.files, nil
+}
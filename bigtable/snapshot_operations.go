@@ -0,0 +1,170 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/longrunning"
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// SnapshotOperation is a handle to a SnapshotTable long-running operation
+// in progress, returned by AdminClient.SnapshotTableOperation or
+// AdminClient.SnapshotTableOperationByName.
+//
+// This is a private alpha release of Cloud Bigtable snapshots; see
+// SnapshotTable's doc comment.
+//
+// Deprecated: use CopyBackupOperation with AdminClient.CopyBackupAsync
+// instead, for the GA Backups equivalent of a long-running copy/snapshot
+// handle.
+type SnapshotOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// AdminClient.SnapshotTableOperationByName to reattach to later.
+func (o *SnapshotOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *SnapshotOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md.
+func (o *SnapshotOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *SnapshotOperation) Poll(ctx context.Context) (bool, error) {
+	var snap btapb.Snapshot
+	return o.op.Poll(ctx, &snap)
+}
+
+// Wait blocks until the operation finishes and returns the resulting
+// snapshot's metadata.
+func (o *SnapshotOperation) Wait(ctx context.Context) (*SnapshotInfo, error) {
+	var snap btapb.Snapshot
+	if err := o.op.Wait(ctx, &snap); err != nil {
+		return nil, err
+	}
+	return newSnapshotInfo(&snap)
+}
+
+// SnapshotTableOperationByName returns a SnapshotOperation handle for the
+// long-running operation named name (as previously reported by another
+// SnapshotOperation's Name), so a process that crashed mid-snapshot can
+// reattach to it on restart instead of losing track of it.
+func (ac *AdminClient) SnapshotTableOperationByName(name string) *SnapshotOperation {
+	return &SnapshotOperation{op: longrunning.InternalNewOperation(ac.lroClient, &longrunningpb.Operation{Name: name})}
+}
+
+// SnapshotTableOperation is like SnapshotTable, but returns a
+// SnapshotOperation handle instead of blocking until the snapshot
+// finishes.
+//
+// Deprecated: use AdminClient.CreateBackup/CreateBackupWithOptions
+// instead.
+func (ac *AdminClient) SnapshotTableOperation(ctx context.Context, table, cluster, snapshot string, ttl time.Duration) (*SnapshotOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
+
+	var ttlProto *durationpb.Duration
+	if ttl > 0 {
+		ttlProto = durationpb.New(ttl)
+	}
+
+	req := &btapb.SnapshotTableRequest{
+		Name:       prefix + "/tables/" + table,
+		Cluster:    prefix + "/clusters/" + cluster,
+		SnapshotId: snapshot,
+		Ttl:        ttlProto,
+	}
+	op, err := ac.tClient.SnapshotTable(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
+}
+
+// CreateTableFromSnapshotOperation is like CreateTableFromSnapshot, but
+// returns a TableOperation handle instead of blocking until creation
+// finishes.
+//
+// Deprecated: use AdminClient.RestoreTableOperation instead.
+func (ac *AdminClient) CreateTableFromSnapshotOperation(ctx context.Context, table, cluster, snapshot string) (*TableOperation, error) {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
+	snapshotPath := prefix + "/clusters/" + cluster + "/snapshots/" + snapshot
+
+	req := &btapb.CreateTableFromSnapshotRequest{
+		Parent:         prefix,
+		TableId:        table,
+		SourceSnapshot: snapshotPath,
+	}
+	op, err := ac.tClient.CreateTableFromSnapshot(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &TableOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
+}
+
+// RestoreTableOperation is RestoreTable, but returns a TableOperation
+// handle instead of blocking until the restore finishes.
+func (ac *AdminClient) RestoreTableOperation(ctx context.Context, table, cluster, backup string) (*TableOperation, error) {
+	return ac.RestoreTableFromOperation(ctx, ac.instance, table, cluster, backup)
+}
+
+// RestoreTableFromOperation is RestoreTableFrom, but returns a
+// TableOperation handle instead of blocking until the restore finishes.
+//
+// Unlike RestoreTableFrom, it doesn't accept a "gs://" backup archive
+// URI: restoring one is really an ImportBackup-then-RestoreTable-then-
+// DeleteBackup sequence (see RestoreTableFromGCS), not a single LRO, so
+// there's no one operation handle to hand back.
+func (ac *AdminClient) RestoreTableFromOperation(ctx context.Context, sourceInstance, table, sourceCluster, backup string) (*TableOperation, error) {
+	if strings.HasPrefix(backup, "gs://") {
+		return nil, errors.New("bigtable: RestoreTableFromOperation doesn't support \"gs://\" backup archives; use RestoreTableFromGCS instead")
+	}
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	parent := ac.instancePrefix()
+	sourceBackupPath := backup
+	if !strings.HasPrefix(backup, "projects/") {
+		sourceBackupPath = ac.backupPath(sourceCluster, sourceInstance, backup)
+	}
+	req := &btapb.RestoreTableRequest{
+		Parent:  parent,
+		TableId: table,
+		Source:  &btapb.RestoreTableRequest_Backup{Backup: sourceBackupPath},
+	}
+	op, err := ac.tClient.RestoreTable(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &TableOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"time"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"cloud.google.com/go/civil"
+)
+
+// QueryColumn describes one column of a query result, as reported by
+// the server's ResultSetMetadata alongside the first batch of rows.
+type QueryColumn struct {
+	Name string
+	// Aggregate is true for a column produced by an aggregate function
+	// (COUNT, SUM, ...) in a GROUP BY query, as opposed to one selected
+	// directly from a table or view.
+	Aggregate bool
+
+	typ *btpb.Type
+}
+
+// structFieldWithValue is one named field of a decoded Struct.
+type structFieldWithValue struct {
+	Name  string
+	Value interface{}
+}
+
+// Struct is a decoded SQL STRUCT value: an ordered sequence of named
+// fields, each holding a value of the kind GetByIndex/GetByName on a
+// ResultRow would produce for a top-level column of that same type.
+// Field names aren't necessarily unique; GetByName returns the first
+// match.
+type Struct struct {
+	fields      []structFieldWithValue
+	nameToIndex map[string][]int
+}
+
+func newStruct(fields []structFieldWithValue) Struct {
+	nameToIndex := make(map[string][]int, len(fields))
+	for i, f := range fields {
+		nameToIndex[f.Name] = append(nameToIndex[f.Name], i)
+	}
+	return Struct{fields: fields, nameToIndex: nameToIndex}
+}
+
+// Len returns the number of fields in s.
+func (s Struct) Len() int { return len(s.fields) }
+
+// FieldName returns the name of s's i'th field.
+func (s Struct) FieldName(i int) string { return s.fields[i].Name }
+
+// GetByIndex decodes s's i'th field into dest, a pointer to a type
+// matching the field's SQL type (see ResultRow.GetByIndex for the
+// supported destination types).
+func (s Struct) GetByIndex(i int, dest interface{}) error {
+	if i < 0 || i >= len(s.fields) {
+		return fmt.Errorf("bigtable: struct field index %d out of range [0,%d)", i, len(s.fields))
+	}
+	return assignSQLValue(dest, s.fields[i].Value)
+}
+
+// GetByName decodes s's field named name into dest. If more than one
+// field shares name, the first is used.
+func (s Struct) GetByName(name string, dest interface{}) error {
+	idxs, ok := s.nameToIndex[name]
+	if !ok {
+		return fmt.Errorf("bigtable: struct has no field named %q", name)
+	}
+	return s.GetByIndex(idxs[0], dest)
+}
+
+// ResultRow is one row of a BoundStatement.Execute result.
+type ResultRow struct {
+	columns []QueryColumn
+	values  []interface{}
+}
+
+// Columns returns the row's column names, in position order (the same
+// order GetByIndex expects).
+func (rr ResultRow) Columns() []string {
+	names := make([]string, len(rr.columns))
+	for i, c := range rr.columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// GetByIndex decodes the column at position i into dest, which must be
+// a non-nil pointer to a type matching the column's SQL type:
+//
+//	BytesSQLType      *[]byte
+//	StringSQLType     *string
+//	Int64SQLType      *int64
+//	Float32SQLType    *float32
+//	Float64SQLType    *float64
+//	BoolSQLType       *bool
+//	TimestampSQLType  *time.Time
+//	DateSQLType       *civil.Date
+//	a STRUCT column    *Struct
+//	ArraySQLType       *[]T (T per the element type above), or *[]any if
+//	                   any element of the array is NULL
+//	a column family     *map[string][]byte, or *map[string]int64 for an
+//	                   aggregate-valued family, keyed by qualifier
+//	a WITH_HISTORY      *[]Struct for a single column, or
+//	  column/family      *map[string][]Struct for a whole family
+//
+// If the column's value is SQL NULL, dest is set to its zero value.
+func (rr ResultRow) GetByIndex(i int, dest interface{}) error {
+	if i < 0 || i >= len(rr.values) {
+		return fmt.Errorf("bigtable: result column index %d out of range [0,%d)", i, len(rr.values))
+	}
+	return assignSQLValue(dest, rr.values[i])
+}
+
+// GetByName decodes the column named name into dest. See GetByIndex for
+// the supported destination types.
+func (rr ResultRow) GetByName(name string, dest interface{}) error {
+	for i, c := range rr.columns {
+		if c.Name == name {
+			return rr.GetByIndex(i, dest)
+		}
+	}
+	return fmt.Errorf("bigtable: result has no column named %q", name)
+}
+
+// assignSQLValue copies val (as produced by decodeValue) into dest, a
+// pointer to the Go type a caller expects for that column.
+func assignSQLValue(dest interface{}, val interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("bigtable: GetByIndex/GetByName destination must be a non-nil pointer, got %T", dest)
+	}
+	elem := dv.Elem()
+	if val == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+	if s, ok := val.([]interface{}); ok && len(s) == 0 && elem.Kind() == reflect.Slice {
+		// An empty ARRAY decodes to an empty []any (see decodeArray),
+		// which fits any slice destination.
+		elem.Set(reflect.MakeSlice(elem.Type(), 0, 0))
+		return nil
+	}
+	vv := reflect.ValueOf(val)
+	if vv.Type().AssignableTo(elem.Type()) {
+		elem.Set(vv)
+		return nil
+	}
+	if vv.Type().ConvertibleTo(elem.Type()) {
+		elem.Set(vv.Convert(elem.Type()))
+		return nil
+	}
+	return fmt.Errorf("bigtable: cannot assign query result of type %T to destination of type %s", val, elem.Type())
+}
+
+// decodeValue decodes v, whose declared SQL type is t, into the Go value
+// GetByIndex/GetByName return for it. t may be nil, for legacy
+// untyped-value decoding; the oneof case alone is used in that case.
+func decodeValue(t *btpb.Type, v *btpb.Value) (interface{}, error) {
+	if v == nil || v.GetKind() == nil {
+		return nil, nil
+	}
+	if t == nil {
+		return decodeQueryValue(v), nil
+	}
+	switch k := t.GetKind().(type) {
+	case *btpb.Type_BytesType:
+		return v.GetBytesValue(), nil
+	case *btpb.Type_StringType:
+		return v.GetStringValue(), nil
+	case *btpb.Type_Int64Type:
+		return v.GetIntValue(), nil
+	case *btpb.Type_Float32Type:
+		return float32(v.GetFloatValue()), nil
+	case *btpb.Type_Float64Type:
+		return v.GetFloatValue(), nil
+	case *btpb.Type_BoolType:
+		return v.GetBoolValue(), nil
+	case *btpb.Type_TimestampType:
+		return v.GetTimestampValue().AsTime(), nil
+	case *btpb.Type_DateType:
+		d := v.GetDateValue()
+		return civil.Date{Year: int(d.GetYear()), Month: time.Month(d.GetMonth()), Day: int(d.GetDay())}, nil
+	case *btpb.Type_ArrayType:
+		return decodeArray(k.ArrayType.GetElementType(), v.GetArrayValue().GetValues())
+	case *btpb.Type_StructType:
+		return decodeStruct(k.StructType, v.GetArrayValue().GetValues())
+	case *btpb.Type_MapType:
+		return decodeMap(k.MapType, v.GetArrayValue().GetValues())
+	case *btpb.Type_AggregateType:
+		return decodeValue(k.AggregateType.GetOutputType(), v)
+	default:
+		return decodeQueryValue(v), nil
+	}
+}
+
+// decodeArray decodes an ARRAY<elemType> value. The result is a []any if
+// any element is NULL, otherwise a slice of the concrete Go type
+// matching elemType (e.g. []int64, []string, []Struct). An empty array
+// decodes to an empty []any, which assignSQLValue accepts for any slice
+// destination.
+func decodeArray(elemType *btpb.Type, vals []*btpb.Value) (interface{}, error) {
+	out := make([]interface{}, len(vals))
+	hasNull := false
+	for i, v := range vals {
+		dv, err := decodeValue(elemType, v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = dv
+		if dv == nil {
+			hasNull = true
+		}
+	}
+	if hasNull {
+		return out, nil
+	}
+	return typedSlice(out), nil
+}
+
+// typedSlice converts a []any of homogeneously-typed, non-nil values
+// into a slice of their concrete type.
+func typedSlice(vals []interface{}) interface{} {
+	if len(vals) == 0 {
+		return []interface{}{}
+	}
+	switch vals[0].(type) {
+	case []byte:
+		s := make([][]byte, len(vals))
+		for i, v := range vals {
+			s[i] = v.([]byte)
+		}
+		return s
+	case string:
+		s := make([]string, len(vals))
+		for i, v := range vals {
+			s[i] = v.(string)
+		}
+		return s
+	case int64:
+		s := make([]int64, len(vals))
+		for i, v := range vals {
+			s[i] = v.(int64)
+		}
+		return s
+	case float32:
+		s := make([]float32, len(vals))
+		for i, v := range vals {
+			s[i] = v.(float32)
+		}
+		return s
+	case float64:
+		s := make([]float64, len(vals))
+		for i, v := range vals {
+			s[i] = v.(float64)
+		}
+		return s
+	case bool:
+		s := make([]bool, len(vals))
+		for i, v := range vals {
+			s[i] = v.(bool)
+		}
+		return s
+	case time.Time:
+		s := make([]time.Time, len(vals))
+		for i, v := range vals {
+			s[i] = v.(time.Time)
+		}
+		return s
+	case civil.Date:
+		s := make([]civil.Date, len(vals))
+		for i, v := range vals {
+			s[i] = v.(civil.Date)
+		}
+		return s
+	case Struct:
+		s := make([]Struct, len(vals))
+		for i, v := range vals {
+			s[i] = v.(Struct)
+		}
+		return s
+	default:
+		return vals
+	}
+}
+
+// decodeStruct decodes a STRUCT value whose field names/types are st.
+func decodeStruct(st *btpb.Type_Struct, vals []*btpb.Value) (Struct, error) {
+	fields := make([]structFieldWithValue, len(st.GetFields()))
+	for i, f := range st.GetFields() {
+		var v *btpb.Value
+		if i < len(vals) {
+			v = vals[i]
+		}
+		dv, err := decodeValue(f.GetType(), v)
+		if err != nil {
+			return Struct{}, err
+		}
+		fields[i] = structFieldWithValue{Name: f.GetFieldName(), Value: dv}
+	}
+	return newStruct(fields), nil
+}
+
+// decodeMap decodes a column-family-valued MAP column, whose entries are
+// key/value pairs each encoded as a 2-element ArrayValue. Keys decode to
+// a base64-encoded string when the key type isn't already a string, to
+// match how a column family's binary qualifiers are surfaced elsewhere
+// in this package (e.g. Row's map keys).
+func decodeMap(mt *btpb.Type_Map, entries []*btpb.Value) (interface{}, error) {
+	m := make(map[string]interface{}, len(entries))
+	for _, e := range entries {
+		pair := e.GetArrayValue().GetValues()
+		if len(pair) != 2 {
+			continue
+		}
+		k, err := decodeValue(mt.GetKeyType(), pair[0])
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeValue(mt.GetValueType(), pair[1])
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			if b, ok := k.([]byte); ok {
+				key = base64.StdEncoding.EncodeToString(b)
+			} else {
+				key = fmt.Sprint(k)
+			}
+		}
+		m[key] = v
+	}
+	return typedMap(mt.GetValueType(), m), nil
+}
+
+// typedMap converts a map[string]any whose values all decoded per
+// valType into the concrete map type GetByIndex/GetByName return for a
+// column family (so an empty family still decodes to the right map type).
+func typedMap(valType *btpb.Type, m map[string]interface{}) interface{} {
+	switch valType.GetKind().(type) {
+	case *btpb.Type_Int64Type:
+		out := make(map[string]int64, len(m))
+		for k, v := range m {
+			if v != nil {
+				out[k] = v.(int64)
+			}
+		}
+		return out
+	case *btpb.Type_ArrayType:
+		out := make(map[string][]Struct, len(m))
+		for k, v := range m {
+			if s, ok := v.([]Struct); ok {
+				out[k] = s
+			}
+		}
+		return out
+	default:
+		out := make(map[string][]byte, len(m))
+		for k, v := range m {
+			if v != nil {
+				out[k] = v.([]byte)
+			}
+		}
+		return out
+	}
+}
+
+// decodeQueryValue decodes v using only its oneof case, with no schema
+// type to disambiguate (e.g. Float32 vs Float64, or an ARRAY/STRUCT
+// element type). Used as a fallback when a column's declared type isn't
+// available.
+func decodeQueryValue(v *btpb.Value) interface{} {
+	switch k := v.GetKind().(type) {
+	case *btpb.Value_StringValue:
+		return k.StringValue
+	case *btpb.Value_BytesValue:
+		return k.BytesValue
+	case *btpb.Value_IntValue:
+		return k.IntValue
+	case *btpb.Value_FloatValue:
+		return k.FloatValue
+	case *btpb.Value_BoolValue:
+		return k.BoolValue
+	case *btpb.Value_TimestampValue:
+		return k.TimestampValue.AsTime()
+	default:
+		return nil
+	}
+}
+
+// sliceElements returns v's elements as a []any, for a v whose
+// reflect.Kind is Slice (supporting both []T and []any inputs to
+// ArraySQLType.encodeValue).
+func sliceElements(v interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice, got %T", v)
+	}
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out, nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"fmt"
+	"time"
+
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
+	"cloud.google.com/go/civil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SQLType declares the type of a named parameter passed to
+// Client.PrepareStatement, so the server knows how to parse a query's
+// @param placeholders. Use one of the concrete Xxx types below; the
+// zero value of each is ready to use (e.g. BytesSQLType{}).
+type SQLType interface {
+	sqlType() *btpb.Type
+	// encodeValue converts a Go value bound for this parameter (via
+	// PreparedStatement.Bind) to wire form. v is never nil; Bind handles
+	// the param-omitted/NULL case itself.
+	encodeValue(v interface{}) (*btpb.Value, error)
+}
+
+// BytesSQLType declares a []byte-valued parameter.
+type BytesSQLType struct{}
+
+func (BytesSQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_BytesType{BytesType: &btpb.Type_Bytes{}}}
+}
+
+func (BytesSQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: BytesSQLType requires a []byte value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_BytesValue{BytesValue: b}}, nil
+}
+
+// StringSQLType declares a string-valued parameter.
+type StringSQLType struct{}
+
+func (StringSQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_StringType{StringType: &btpb.Type_String{}}}
+}
+
+func (StringSQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: StringSQLType requires a string value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_StringValue{StringValue: s}}, nil
+}
+
+// Int64SQLType declares an int64-valued parameter.
+type Int64SQLType struct{}
+
+func (Int64SQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_Int64Type{Int64Type: &btpb.Type_Int64{}}}
+}
+
+func (Int64SQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	n, ok := v.(int64)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: Int64SQLType requires an int64 value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_IntValue{IntValue: n}}, nil
+}
+
+// Float32SQLType declares a float32-valued parameter.
+type Float32SQLType struct{}
+
+func (Float32SQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_Float32Type{Float32Type: &btpb.Type_Float32{}}}
+}
+
+func (Float32SQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	f, ok := v.(float32)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: Float32SQLType requires a float32 value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_FloatValue{FloatValue: float64(f)}}, nil
+}
+
+// Float64SQLType declares a float64-valued parameter.
+type Float64SQLType struct{}
+
+func (Float64SQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_Float64Type{Float64Type: &btpb.Type_Float64{}}}
+}
+
+func (Float64SQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: Float64SQLType requires a float64 value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_FloatValue{FloatValue: f}}, nil
+}
+
+// BoolSQLType declares a bool-valued parameter.
+type BoolSQLType struct{}
+
+func (BoolSQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_BoolType{BoolType: &btpb.Type_Bool{}}}
+}
+
+func (BoolSQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: BoolSQLType requires a bool value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_BoolValue{BoolValue: b}}, nil
+}
+
+// TimestampSQLType declares a time.Time-valued parameter.
+type TimestampSQLType struct{}
+
+func (TimestampSQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_TimestampType{TimestampType: &btpb.Type_Timestamp{}}}
+}
+
+func (TimestampSQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	t, ok := v.(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: TimestampSQLType requires a time.Time value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_TimestampValue{TimestampValue: timestamppb.New(t)}}, nil
+}
+
+// DateSQLType declares a civil.Date-valued parameter.
+type DateSQLType struct{}
+
+func (DateSQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_DateType{DateType: &btpb.Type_Date{}}}
+}
+
+func (DateSQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	d, ok := v.(civil.Date)
+	if !ok {
+		return nil, fmt.Errorf("bigtable: DateSQLType requires a civil.Date value, got %T", v)
+	}
+	return &btpb.Value{Kind: &btpb.Value_DateValue{DateValue: &btpb.Date{
+		Year:  int32(d.Year),
+		Month: int32(d.Month),
+		Day:   int32(d.Day),
+	}}}, nil
+}
+
+// ArraySQLType declares a parameter whose value is a slice of ElemType.
+// The Go value bound via Bind must be a []T matching ElemType (e.g.
+// []int64 for ArraySQLType{ElemType: Int64SQLType{}}), or []any if the
+// array may contain a NULL element.
+type ArraySQLType struct {
+	ElemType SQLType
+}
+
+func (a ArraySQLType) sqlType() *btpb.Type {
+	return &btpb.Type{Kind: &btpb.Type_ArrayType{ArrayType: &btpb.Type_Array{ElementType: a.ElemType.sqlType()}}}
+}
+
+func (a ArraySQLType) encodeValue(v interface{}) (*btpb.Value, error) {
+	elems, err := sliceElements(v)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: ArraySQLType requires a slice value: %w", err)
+	}
+	values := make([]*btpb.Value, len(elems))
+	for i, e := range elems {
+		if e == nil {
+			values[i] = &btpb.Value{}
+			continue
+		}
+		ev, err := a.ElemType.encodeValue(e)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = ev
+	}
+	return &btpb.Value{Kind: &btpb.Value_ArrayValue{ArrayValue: &btpb.ArrayValue{Values: values}}}, nil
+}
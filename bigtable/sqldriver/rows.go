@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+
+	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/civil"
+)
+
+// rowOrErr is one item off a rows' result channel: either a decoded row
+// or the terminal error BoundStatement.Execute returned.
+type rowOrErr struct {
+	row bigtable.ResultRow
+	err error
+}
+
+// rows adapts BoundStatement.Execute's push-style callback to
+// database/sql/driver.Rows' pull-style Next, by running Execute in its
+// own goroutine and handing rows across a channel as they're decoded.
+// The channel is unbuffered, so the Execute goroutine blocks on each row
+// until Next takes it; Execute itself decodes a whole response batch at a
+// time.
+type rows struct {
+	cancel  context.CancelFunc
+	ch      chan rowOrErr
+	cols    []string
+	pending *rowOrErr
+}
+
+var _ driver.Rows = (*rows)(nil)
+
+func newRows(ctx context.Context, bs *bigtable.BoundStatement) *rows {
+	ctx, cancel := context.WithCancel(ctx)
+	r := &rows{cancel: cancel, ch: make(chan rowOrErr)}
+	go func() {
+		defer close(r.ch)
+		err := bs.Execute(ctx, func(row bigtable.ResultRow) bool {
+			select {
+			case r.ch <- rowOrErr{row: row}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil {
+			select {
+			case r.ch <- rowOrErr{err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return r
+}
+
+// Columns blocks until either the first row arrives (from which the
+// column names are read) or the query completes with no rows, in which
+// case Columns returns nil: a zero-row result never surfaces a schema,
+// since BoundStatement.Execute only reports column names alongside a
+// decoded row.
+func (r *rows) Columns() []string {
+	if r.cols != nil {
+		return r.cols
+	}
+	item, ok := <-r.ch
+	if !ok {
+		return nil
+	}
+	r.cols = item.row.Columns()
+	r.pending = &item
+	return r.cols
+}
+
+func (r *rows) Close() error {
+	r.cancel()
+	for range r.ch {
+		// Drain so the Execute goroutine's send (if any) doesn't leak.
+	}
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	var item rowOrErr
+	if r.pending != nil {
+		item = *r.pending
+		r.pending = nil
+	} else {
+		v, ok := <-r.ch
+		if !ok {
+			return io.EOF
+		}
+		item = v
+	}
+	if item.err != nil {
+		return item.err
+	}
+	if r.cols == nil {
+		r.cols = item.row.Columns()
+	}
+	for i := range dest {
+		var v interface{}
+		if err := item.row.GetByIndex(i, &v); err != nil {
+			return err
+		}
+		dest[i] = driverValue(v)
+	}
+	return nil
+}
+
+// driverValue narrows a decoded column value to one of
+// database/sql/driver.Value's blessed scalar types where a lossless
+// conversion exists. Types with no such conversion (Struct, []Struct,
+// map[string][]byte, map[string]int64, map[string][]Struct, and typed
+// array slices like []int64) are passed through unchanged; database/sql
+// only accepts those when Scanned into a *interface{} destination.
+func driverValue(v interface{}) driver.Value {
+	switch x := v.(type) {
+	case float32:
+		return float64(x)
+	case civil.Date:
+		return x.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sqldriver adapts cloud.google.com/go/bigtable's
+// PrepareStatement/Bind/Execute SQL surface to a database/sql/driver,
+// so it can be driven from the standard library's database/sql package
+// (and anything built on top of it, like an ORM or migration tool)
+// instead of calling bigtable.Client directly.
+//
+// Register once, then use it like any other database/sql driver:
+//
+//	sql.Register("bigtable", &sqldriver.Driver{})
+//	db, err := sql.Open("bigtable", "my-project/my-instance")
+//	...
+//	rows, err := db.QueryContext(ctx, "SELECT * FROM t WHERE _key=@k", sql.Named("k", []byte("row-1")))
+//
+// The driver is query-only: Bigtable's SQL surface has no notion of a
+// transaction or of an Exec that returns rows affected, so Exec and
+// Begin both return errors.
+package sqldriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/civil"
+)
+
+// Driver is a database/sql/driver.Driver backed by a bigtable.Client.
+type Driver struct{}
+
+var _ driver.Driver = (*Driver)(nil)
+
+// Open returns a new connection to the Bigtable instance named
+// "project/instance". It's invoked by database/sql on demand (e.g. as
+// sql.DB's pool needs more connections); there's no way to pass
+// option.ClientOption through a DSN string, so a connection opened this
+// way always uses application default credentials. Callers that need
+// custom client options should construct a *bigtable.Client themselves
+// and build queries directly against it instead of going through
+// database/sql.
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	project, instance, ok := strings.Cut(name, "/")
+	if !ok {
+		return nil, fmt.Errorf("sqldriver: data source name %q must be \"project/instance\"", name)
+	}
+	c, err := bigtable.NewClient(context.Background(), project, instance)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{c: c}, nil
+}
+
+// conn is a database/sql/driver.Conn wrapping a *bigtable.Client.
+type conn struct {
+	c *bigtable.Client
+}
+
+var (
+	_ driver.Conn               = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+)
+
+func (cn *conn) Prepare(query string) (driver.Stmt, error) {
+	return cn.PrepareContext(context.Background(), query)
+}
+
+func (cn *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return &stmt{c: cn.c, query: query}, nil
+}
+
+// Begin is unsupported: Bigtable SQL queries aren't transactional.
+func (cn *conn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("sqldriver: transactions aren't supported")
+}
+
+func (cn *conn) Close() error {
+	return cn.c.Close()
+}
+
+// stmt is a database/sql/driver.Stmt that prepares and binds its query
+// text lazily, on every QueryContext call, since a bigtable.SQLType for
+// each named parameter is only known once we see the argument values
+// QueryContext was called with.
+type stmt struct {
+	c     *bigtable.Client
+	query string
+}
+
+var (
+	_ driver.Stmt              = (*stmt)(nil)
+	_ driver.StmtQueryContext  = (*stmt)(nil)
+	_ driver.NamedValueChecker = (*stmt)(nil)
+)
+
+func (s *stmt) Close() error { return nil }
+
+// NumInput returns -1: Bigtable SQL statements take named (@name)
+// parameters, not positional ones, so there's no fixed input count to
+// report ahead of seeing the caller's sql.Named arguments.
+func (s *stmt) NumInput() int { return -1 }
+
+// Exec is unsupported: every Bigtable SQL statement is a query.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("sqldriver: Exec is unsupported; Bigtable SQL statements are queries, use Query/QueryContext")
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return s.QueryContext(context.Background(), named)
+}
+
+// CheckNamedValue accepts every argument value as-is, bypassing
+// database/sql's default driver.Value conversion: QueryContext infers a
+// bigtable.SQLType from each value's concrete Go type, which requires
+// seeing types (float32, civil.Date, ...) that conversion would
+// otherwise flatten away.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	return nil
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	paramTypes := make(map[string]bigtable.SQLType, len(args))
+	paramValues := make(map[string]interface{}, len(args))
+	for _, a := range args {
+		if a.Name == "" {
+			return nil, fmt.Errorf("sqldriver: positional query parameters aren't supported; use sql.Named(name, value)")
+		}
+		t, err := sqlTypeOf(a.Value)
+		if err != nil {
+			return nil, fmt.Errorf("sqldriver: parameter %q: %w", a.Name, err)
+		}
+		paramTypes[a.Name] = t
+		v := a.Value
+		if n, ok := v.(int); ok {
+			// Int64SQLType binds only int64 values.
+			v = int64(n)
+		}
+		paramValues[a.Name] = v
+	}
+
+	ps, err := s.c.PrepareStatement(ctx, s.query, paramTypes)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := ps.Bind(paramValues)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(ctx, bs), nil
+}
+
+// sqlTypeOf infers a bigtable.SQLType from a query parameter's concrete
+// Go type. ArraySQLType parameters aren't inferable this way, since the
+// element type can't be recovered from an empty or nil slice value;
+// callers needing an array parameter should call
+// bigtable.Client.PrepareStatement/Bind directly instead of going
+// through database/sql.
+func sqlTypeOf(v interface{}) (bigtable.SQLType, error) {
+	switch v.(type) {
+	case []byte:
+		return bigtable.BytesSQLType{}, nil
+	case string:
+		return bigtable.StringSQLType{}, nil
+	case int64:
+		return bigtable.Int64SQLType{}, nil
+	case int:
+		return bigtable.Int64SQLType{}, nil
+	case float32:
+		return bigtable.Float32SQLType{}, nil
+	case float64:
+		return bigtable.Float64SQLType{}, nil
+	case bool:
+		return bigtable.BoolSQLType{}, nil
+	case time.Time:
+		return bigtable.TimestampSQLType{}, nil
+	case civil.Date:
+		return bigtable.DateSQLType{}, nil
+	default:
+		return nil, fmt.Errorf("cannot infer a bigtable.SQLType for a %T value", v)
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/internal/optional"
+	"google.golang.org/api/iterator"
+)
+
+// backupPlanNamePrefix is the prefix RunBackupPlanReconciler gives the
+// extra-rule backups it creates directly (TableBackupPlan.Rules[0]'s
+// backups are instead created by the table's own Table_AutomatedBackupPolicy_,
+// the same as a TableAutomatedBackupPolicy's), so reconciliation can tell
+// its own backups apart and so a deterministic name doubles as an
+// idempotency key, the same way scheduledBackupNamePrefix does for
+// BackupSchedule.
+const backupPlanNamePrefix = "_backup_plan_"
+
+// BackupPlanRule is one schedule within a TableBackupPlan: a new backup is
+// created every Every and kept for Retention, independently of the plan's
+// other rules.
+type BackupPlanRule struct {
+	Every     time.Duration
+	Retention time.Duration
+}
+
+func (r BackupPlanRule) validate() error {
+	if r.Every <= 0 {
+		return errors.New("bigtable: BackupPlanRule.Every must be positive")
+	}
+	if r.Retention <= 0 {
+		return errors.New("bigtable: BackupPlanRule.Retention must be positive")
+	}
+	return nil
+}
+
+// TableBackupPlan is a TableAutomatedBackupConfig that extends
+// TableAutomatedBackupPolicy with multiple retention tiers and
+// cross-cluster/cross-region copies, driven by RunBackupPlanReconciler
+// instead of entirely server-side.
+//
+// Cloud Bigtable's Backup resource has no labels field this package can
+// reach, so Selectors isn't written to any server-side resource the way
+// GCE/GCS labels would be; RunBackupPlanReconciler instead folds it into
+// each backup it creates directly (see backupPlanBackupName), so
+// operators can still group or filter those backups by name, at the cost
+// of it not being a queryable resource label and not applying at all to
+// Rules[0]'s backups, which the table's automated backup policy names on
+// its own.
+type TableBackupPlan struct {
+	// Cluster is the cluster new backups are created in.
+	Cluster string
+	// Rules are the plan's retention tiers. Rules[0] additionally becomes
+	// the table's primary Table_AutomatedBackupPolicy_ (see toProto),
+	// since that proto has no way to express more than one
+	// schedule/retention pair; RunBackupPlanReconciler still creates and
+	// expires Rules[1:]'s backups directly. At least one rule is required.
+	Rules []BackupPlanRule
+	// CopyDestinations are copied to from every backup RunBackupPlanReconciler
+	// observes for this table's primary rule (Rules[0]), via
+	// AdminClient.CopyBackups, immediately after each one first appears.
+	CopyDestinations []CopyBackupDestination
+	// Selectors groups the backups RunBackupPlanReconciler creates
+	// directly for Rules[1:]; see the type doc for its labels limitation.
+	Selectors map[string]string
+}
+
+func (*TableBackupPlan) isTableAutomatedBackupConfig() {}
+
+func (p *TableBackupPlan) validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("bigtable: TableBackupPlan.Rules must have at least one rule")
+	}
+	for _, r := range p.Rules {
+		if err := r.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toProto translates p.Rules[0] into a Table_AutomatedBackupPolicy_, the
+// only rule the underlying proto can express.
+func (p *TableBackupPlan) toProto() (*btapb.Table_AutomatedBackupPolicy_, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	primary := p.Rules[0]
+	policy := TableAutomatedBackupPolicy{
+		RetentionPeriod: optional.Duration(primary.Retention),
+		Frequency:       optional.Duration(primary.Every),
+	}
+	return policy.toProto()
+}
+
+// UpdateTableWithBackupPlan updates tableID's primary automated backup
+// policy from plan.Rules[0], and registers plan so RunBackupPlanReconciler
+// creates plan.Rules[1:]'s extra backups and copies every primary-rule
+// backup to plan.CopyDestinations. Only one plan per table is
+// registered through a given AdminClient at a time; a later call for
+// the same table replaces the earlier plan and its copy bookkeeping.
+func (ac *AdminClient) UpdateTableWithBackupPlan(ctx context.Context, tableID string, plan TableBackupPlan) error {
+	if err := plan.validate(); err != nil {
+		return err
+	}
+	primary := plan.Rules[0]
+	if err := ac.UpdateTableWithAutomatedBackupPolicy(ctx, tableID, TableAutomatedBackupPolicy{
+		RetentionPeriod: optional.Duration(primary.Retention),
+		Frequency:       optional.Duration(primary.Every),
+	}); err != nil {
+		return err
+	}
+
+	ac.backupPlansMu.Lock()
+	if ac.backupPlans == nil {
+		ac.backupPlans = make(map[string]*registeredBackupPlan)
+	}
+	cp := plan
+	ac.backupPlans[tableID] = &registeredBackupPlan{plan: &cp, copied: make(map[string]bool)}
+	ac.backupPlansMu.Unlock()
+	return nil
+}
+
+// UpdateTableDisableBackupPlan disables tableID's automated backup policy
+// and unregisters its TableBackupPlan, stopping RunBackupPlanReconciler
+// from creating or copying any more of its backups. Backups already
+// created are left in place.
+func (ac *AdminClient) UpdateTableDisableBackupPlan(ctx context.Context, tableID string) error {
+	if err := ac.UpdateTableDisableAutomatedBackupPolicy(ctx, tableID); err != nil {
+		return err
+	}
+	ac.backupPlansMu.Lock()
+	delete(ac.backupPlans, tableID)
+	ac.backupPlansMu.Unlock()
+	return nil
+}
+
+// registeredBackupPlan pairs a TableBackupPlan with the reconciler's
+// bookkeeping of which primary-rule backups it has already started
+// copying, so a restarted reconciler is the only thing that can cause a
+// backup to be copied twice.
+type registeredBackupPlan struct {
+	plan   *TableBackupPlan
+	copied map[string]bool
+}
+
+// backupPlanReconcileInterval is how often RunBackupPlanReconciler checks
+// every registered TableBackupPlan's extra rules and copy destinations.
+const backupPlanReconcileInterval = time.Minute
+
+// RunBackupPlanReconciler drives every TableBackupPlan registered via
+// UpdateTableWithBackupPlan until ctx is done: on each tick, for each
+// plan, it creates any of Rules[1:]'s backups that are due for the
+// current period (Rules[0] is already handled server-side by the table's
+// automated backup policy), deletes backups past their rule's Retention,
+// and starts a CopyBackups fan-out to CopyDestinations for any
+// Rules[0]-created backup it hasn't copied yet. Callers run it in their
+// own goroutine, e.g. `go ac.RunBackupPlanReconciler(ctx)`; it returns
+// ctx.Err() once ctx is done.
+func (ac *AdminClient) RunBackupPlanReconciler(ctx context.Context) error {
+	ticker := time.NewTicker(backupPlanReconcileInterval)
+	defer ticker.Stop()
+	ac.reconcileBackupPlans(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			ac.reconcileBackupPlans(ctx)
+		}
+	}
+}
+
+func (ac *AdminClient) reconcileBackupPlans(ctx context.Context) {
+	ac.backupPlansMu.Lock()
+	tableIDs := make([]string, 0, len(ac.backupPlans))
+	for tableID := range ac.backupPlans {
+		tableIDs = append(tableIDs, tableID)
+	}
+	ac.backupPlansMu.Unlock()
+
+	for _, tableID := range tableIDs {
+		ac.reconcileBackupPlan(ctx, tableID)
+	}
+}
+
+func (ac *AdminClient) reconcileBackupPlan(ctx context.Context, tableID string) {
+	ac.backupPlansMu.Lock()
+	reg, ok := ac.backupPlans[tableID]
+	ac.backupPlansMu.Unlock()
+	if !ok {
+		return
+	}
+	plan := reg.plan
+	now := time.Now()
+
+	existing, err := ac.listBackupPlanBackups(ctx, plan.Cluster, tableID)
+	if err != nil {
+		return
+	}
+
+	for i, rule := range plan.Rules {
+		if i == 0 {
+			// Rules[0]'s backups come from the table's own automated
+			// backup policy; reconcileBackupPlan only watches for them,
+			// below, to copy them. Their expiry is left to that policy.
+			continue
+		}
+		period := now.Truncate(rule.Every)
+		name := backupPlanBackupName(tableID, i, period, plan.Selectors)
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		err := ac.CreateBackupWithOptions(ctx, tableID, plan.Cluster, name, WithExpiry(now.Add(rule.Retention)))
+		if err == nil {
+			existing[name] = nil
+		}
+	}
+
+	for name, info := range existing {
+		rule, ok := backupPlanRuleForName(plan, tableID, name)
+		if !ok || info == nil {
+			continue
+		}
+		if now.Sub(info.StartTime) >= rule.Retention {
+			_ = ac.DeleteBackup(ctx, plan.Cluster, name)
+		}
+	}
+
+	if len(plan.CopyDestinations) == 0 {
+		return
+	}
+	for name, info := range existing {
+		if info == nil || info.SourceTable != tableID {
+			continue
+		}
+		if _, isExtra := backupPlanRuleForName(plan, tableID, name); isExtra {
+			continue
+		}
+		ac.backupPlansMu.Lock()
+		alreadyCopied := reg.copied[name]
+		if !alreadyCopied {
+			reg.copied[name] = true
+		}
+		ac.backupPlansMu.Unlock()
+		if alreadyCopied {
+			continue
+		}
+		if _, err := ac.CopyBackups(ctx, plan.Cluster, name, plan.CopyDestinations, CopyBackupOptions{}); err != nil {
+			ac.backupPlansMu.Lock()
+			delete(reg.copied, name)
+			ac.backupPlansMu.Unlock()
+		}
+	}
+}
+
+// backupPlanBackupName deterministically names the backup
+// RunBackupPlanReconciler creates directly for rule index i (i>0) of
+// tableID's plan in period, folding selectors in sorted key=value form so
+// operators can group or filter these backups by name (see
+// TableBackupPlan's doc comment for why this can't be a real label
+// instead).
+func backupPlanBackupName(tableID string, i int, period time.Time, selectors map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s_r%d", backupPlanNamePrefix, tableID, i)
+	for _, k := range sortedKeys(selectors) {
+		fmt.Fprintf(&b, "_%s-%s", k, selectors[k])
+	}
+	fmt.Fprintf(&b, "_%d", period.Unix())
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+	return keys
+}
+
+// backupPlanRuleForName reports whether name looks like one of plan's
+// Rules[1:] backups (as created by backupPlanBackupName) for tableID, and
+// if so, which rule it belongs to.
+func backupPlanRuleForName(plan *TableBackupPlan, tableID, name string) (BackupPlanRule, bool) {
+	prefix := fmt.Sprintf("%s%s_r", backupPlanNamePrefix, tableID)
+	if !strings.HasPrefix(name, prefix) {
+		return BackupPlanRule{}, false
+	}
+	rest := name[len(prefix):]
+	idx := 0
+	for idx < len(rest) && rest[idx] >= '0' && rest[idx] <= '9' {
+		idx++
+	}
+	if idx == 0 {
+		return BackupPlanRule{}, false
+	}
+	var i int
+	fmt.Sscanf(rest[:idx], "%d", &i)
+	if i <= 0 || i >= len(plan.Rules) {
+		return BackupPlanRule{}, false
+	}
+	return plan.Rules[i], true
+}
+
+// listBackupPlanBackups returns, keyed by name, every backup for tableID
+// in cluster, including ones RunBackupPlanReconciler
+// didn't create itself (e.g. Rules[0]'s, made by the table's automated
+// backup policy), so reconciliation always starts from ListBackups rather
+// than in-memory state for what backups currently exist.
+func (ac *AdminClient) listBackupPlanBackups(ctx context.Context, cluster, tableID string) (map[string]*BackupInfo, error) {
+	out := make(map[string]*BackupInfo)
+	it := ac.Backups(ctx, cluster)
+	for {
+		info, err := it.Next()
+		if err == iterator.Done {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if info.SourceTable != tableID {
+			continue
+		}
+		out[info.Name] = info
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBackupPlanRuleValidate(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		r       BackupPlanRule
+		wantErr bool
+	}{
+		{desc: "ok", r: BackupPlanRule{Every: time.Hour, Retention: 24 * time.Hour}},
+		{desc: "Every not positive", r: BackupPlanRule{Retention: 24 * time.Hour}, wantErr: true},
+		{desc: "Retention not positive", r: BackupPlanRule{Every: time.Hour}, wantErr: true},
+	} {
+		err := test.r.validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: validate() = %v, wantErr %v", test.desc, err, test.wantErr)
+		}
+	}
+}
+
+func TestTableBackupPlanValidate(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		p       TableBackupPlan
+		wantErr bool
+	}{
+		{
+			desc: "single valid rule",
+			p:    TableBackupPlan{Rules: []BackupPlanRule{{Every: time.Hour, Retention: 24 * time.Hour}}},
+		},
+		{
+			desc: "multiple valid rules",
+			p: TableBackupPlan{Rules: []BackupPlanRule{
+				{Every: time.Hour, Retention: 24 * time.Hour},
+				{Every: 24 * time.Hour, Retention: 30 * 24 * time.Hour},
+			}},
+		},
+		{
+			desc:    "no rules",
+			p:       TableBackupPlan{},
+			wantErr: true,
+		},
+		{
+			desc: "one invalid rule among valid ones",
+			p: TableBackupPlan{Rules: []BackupPlanRule{
+				{Every: time.Hour, Retention: 24 * time.Hour},
+				{Every: 0, Retention: 30 * 24 * time.Hour},
+			}},
+			wantErr: true,
+		},
+	} {
+		err := test.p.validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: validate() = %v, wantErr %v", test.desc, err, test.wantErr)
+		}
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	got := sortedKeys(map[string]string{"b": "2", "a": "1", "c": "3"})
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedKeys = %v, want %v", got, want)
+	}
+	if got := sortedKeys(nil); len(got) != 0 {
+		t.Errorf("sortedKeys(nil) = %v, want empty", got)
+	}
+}
+
+func TestBackupPlanBackupNameDeterministicAndOrdered(t *testing.T) {
+	period := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	selectors := map[string]string{"env": "prod", "region": "us"}
+
+	got1 := backupPlanBackupName("t1", 1, period, selectors)
+	got2 := backupPlanBackupName("t1", 1, period, selectors)
+	if got1 != got2 {
+		t.Errorf("backupPlanBackupName is not deterministic: %q vs %q", got1, got2)
+	}
+	want := backupPlanNamePrefix + "t1_r1_env-prod_region-us_" + strconv.FormatInt(period.Unix(), 10)
+	if got1 != want {
+		t.Errorf("backupPlanBackupName = %q, want %q", got1, want)
+	}
+
+	otherRule := backupPlanBackupName("t1", 2, period, selectors)
+	if otherRule == got1 {
+		t.Error("backupPlanBackupName gave the same name for two different rule indexes")
+	}
+}
+
+func TestBackupPlanRuleForName(t *testing.T) {
+	plan := &TableBackupPlan{Rules: []BackupPlanRule{
+		{Every: time.Hour, Retention: 24 * time.Hour},
+		{Every: 24 * time.Hour, Retention: 30 * 24 * time.Hour},
+		{Every: 7 * 24 * time.Hour, Retention: 90 * 24 * time.Hour},
+	}}
+	period := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+
+	name := backupPlanBackupName("t1", 2, period, nil)
+	rule, ok := backupPlanRuleForName(plan, "t1", name)
+	if !ok {
+		t.Fatalf("backupPlanRuleForName(%q) = not found, want rule 2", name)
+	}
+	if rule != plan.Rules[2] {
+		t.Errorf("backupPlanRuleForName(%q) = %+v, want %+v", name, rule, plan.Rules[2])
+	}
+
+	if _, ok := backupPlanRuleForName(plan, "t1", "unrelated-backup-name"); ok {
+		t.Error("backupPlanRuleForName matched a name with no plan prefix")
+	}
+
+	if _, ok := backupPlanRuleForName(plan, "t1", backupPlanNamePrefix+"t1_r0_123"); ok {
+		t.Error("backupPlanRuleForName matched rule index 0, which belongs to the table's automated backup policy, not an extra rule")
+	}
+
+	if _, ok := backupPlanRuleForName(plan, "t1", backupPlanNamePrefix+"t1_r9_123"); ok {
+		t.Error("backupPlanRuleForName matched an out-of-range rule index")
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	btapb "cloud.google.com/go/bigtable/admin/apiv2/adminpb"
+	"cloud.google.com/go/longrunning"
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// TableOperation is a handle to a CreateTable, UpdateTable,
+// CreateTableFromSnapshot, or RestoreTable long-running operation in
+// progress, returned by AdminClient.CreateTableOperation,
+// AdminClient.UpdateTableOperation,
+// AdminClient.CreateTableFromSnapshotOperation,
+// AdminClient.RestoreTableOperation, AdminClient.RestoreTableFromOperation,
+// or AdminClient.ResumeTableOperation.
+// Unlike CreateTableFromConf and the UpdateTableWith* helpers, which
+// block until the operation finishes, these let a caller poll for
+// progress, cancel a slow schema change mid-flight, or reattach to an
+// in-flight update from a process that crashed and restarted.
+type TableOperation struct {
+	op *longrunning.Operation
+}
+
+// Name returns the operation's resource name, for
+// AdminClient.ResumeTableOperation to reattach to later (e.g. across a
+// process restart).
+func (o *TableOperation) Name() string {
+	return o.op.Name()
+}
+
+// Done reports whether the operation has finished, without blocking.
+func (o *TableOperation) Done() bool {
+	return o.op.Done()
+}
+
+// Metadata decodes the operation's current progress metadata into md —
+// an UpdateTableMetadata for a handle from UpdateTableOperation — or
+// returns an error if the server hasn't reported any yet.
+//
+// This package has no cached copy of longrunning.Operation's generated
+// signature to check Metadata's and Cancel's/Delete's exact shape
+// against (Poll, Wait, and Done, already exercised by CopyBackupOperation
+// in admin.go, are the only ones this package has verified),
+// so treat those three as best-effort.
+func (o *TableOperation) Metadata(md proto.Message) error {
+	return o.op.Metadata(md)
+}
+
+// Poll checks once whether the operation has finished, without blocking;
+// ctx governs only this one check.
+func (o *TableOperation) Poll(ctx context.Context) (bool, error) {
+	var tbl btapb.Table
+	return o.op.Poll(ctx, &tbl)
+}
+
+// Wait blocks until the operation finishes.
+func (o *TableOperation) Wait(ctx context.Context) error {
+	var tbl btapb.Table
+	return o.op.Wait(ctx, &tbl)
+}
+
+// Cancel requests that the server abort the operation. The operation
+// isn't necessarily cancelled immediately: poll Done or call Wait to
+// observe the final outcome.
+func (o *TableOperation) Cancel(ctx context.Context) error {
+	return o.op.Cancel(ctx)
+}
+
+// Delete removes the operation's bookkeeping on the server once it's no
+// longer needed. It doesn't cancel an in-flight operation; call Cancel
+// first if that's what's wanted.
+func (o *TableOperation) Delete(ctx context.Context) error {
+	return o.op.Delete(ctx)
+}
+
+// ResumeTableOperation returns a TableOperation handle for the
+// long-running operation named name (as previously reported by another
+// TableOperation's Name), so a process that crashed mid-update can
+// reattach to it on restart instead of losing track of it.
+func (ac *AdminClient) ResumeTableOperation(ctx context.Context, name string) (*TableOperation, error) {
+	return &TableOperation{op: longrunning.InternalNewOperation(ac.lroClient, &longrunningpb.Operation{Name: name})}, nil
+}
+
+// CreateTableOperation is like CreateTableFromConf, but returns a
+// TableOperation handle instead of blocking until creation finishes.
+//
+// CreateTable is a synchronous admin RPC in the real Bigtable API, not a
+// long-running one the way UpdateTable is: by the time this returns, the
+// table already exists and the handle's Done is already true, with no
+// real operation to Cancel. It exists mainly for API symmetry with
+// UpdateTableOperation, so code that creates and updates tables through
+// the same operation-handle-shaped interface doesn't need to special-case
+// which call actually has progress to poll.
+func (ac *AdminClient) CreateTableOperation(ctx context.Context, conf *TableConf) (*TableOperation, error) {
+	req, err := ac.newCreateTableRequestProto(conf)
+	if err != nil {
+		return nil, err
+	}
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	tbl, err := ac.tClient.CreateTable(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	op, err := doneTableOperation(tbl)
+	if err != nil {
+		return nil, err
+	}
+	return &TableOperation{op: longrunning.InternalNewOperation(ac.lroClient, op)}, nil
+}
+
+// doneTableOperation packages resp as an already-completed
+// longrunningpb.Operation, the way CreateTable's synchronous response
+// would look once an async-style caller caught up to it.
+// longrunning.InternalNewOperation(...).Wait/.Poll check Done and unpack
+// Result before ever issuing a GetOperation call, so a synthetic
+// always-done Operation is enough to satisfy TableOperation's callers.
+func doneTableOperation(resp proto.Message) (*longrunningpb.Operation, error) {
+	any, err := anypb.New(resp)
+	if err != nil {
+		return nil, fmt.Errorf("bigtable: packing operation response: %w", err)
+	}
+	return &longrunningpb.Operation{
+		Done:   true,
+		Result: &longrunningpb.Operation_Response{Response: any},
+	}, nil
+}
+
+// UpdateTableOption configures an AdminClient.UpdateTableOperation call,
+// mirroring one of the UpdateTableWith* helpers' single-field updates so
+// it can be run asynchronously and polled/cancelled via a TableOperation.
+type UpdateTableOption interface {
+	set(*btapb.UpdateTableRequest)
+}
+
+type updateChangeStreamRetentionOption time.Duration
+
+func (o updateChangeStreamRetentionOption) set(req *btapb.UpdateTableRequest) {
+	req.UpdateMask.Paths = append(req.UpdateMask.Paths, changeStreamConfigFieldMask+"."+retentionPeriodFieldMaskPath)
+	req.Table.ChangeStreamConfig = &btapb.ChangeStreamConfig{RetentionPeriod: durationpb.New(time.Duration(o))}
+}
+
+// WithUpdateChangeStreamRetention is UpdateTableWithChangeStreamRetention
+// as an UpdateTableOption, for AdminClient.UpdateTableOperation.
+func WithUpdateChangeStreamRetention(retention time.Duration) UpdateTableOption {
+	return updateChangeStreamRetentionOption(retention)
+}
+
+type updateDeletionProtectionOption bool
+
+func (o updateDeletionProtectionOption) set(req *btapb.UpdateTableRequest) {
+	req.UpdateMask.Paths = append(req.UpdateMask.Paths, deletionProtectionFieldMask)
+	req.Table.DeletionProtection = bool(o)
+}
+
+// WithUpdateDeletionProtection is UpdateTableWithDeletionProtection as an
+// UpdateTableOption, for AdminClient.UpdateTableOperation.
+func WithUpdateDeletionProtection(deletionProtection DeletionProtection) UpdateTableOption {
+	return updateDeletionProtectionOption(deletionProtection != Unprotected)
+}
+
+// UpdateTableOperation is like UpdateTableWithChangeStream,
+// UpdateTableWithDeletionProtection, and friends, but applies every opts
+// in a single UpdateTable call and returns a TableOperation handle
+// instead of blocking until the update finishes.
+func (ac *AdminClient) UpdateTableOperation(ctx context.Context, tableID string, opts ...UpdateTableOption) (*TableOperation, error) {
+	req, err := ac.newUpdateTableRequestProto(tableID)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt.set(req)
+	}
+	return ac.newUpdateTableOperation(ctx, req)
+}